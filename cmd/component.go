@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/erNail/verscout/internal/discovery"
+	"github.com/erNail/verscout/internal/gitutils"
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/go-git/go-git/v5"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// addComponentFlag adds the flag for selecting a monorepo component.
+func addComponentFlag(cmd *cobra.Command, component *string) {
+	cmd.Flags().
+		StringVar(
+			component,
+			"component",
+			"",
+			"The name of the monorepo component to use, as defined in the config file",
+		)
+}
+
+// resolveComponent returns the tag format of the version tags, and the path globs of the files to take into account.
+// If a component name is given, the tag prefix and paths of that component are used.
+// Otherwise, the tag format of the config is used, and all files are taken into account.
+func resolveComponent(
+	config semverutils.CompiledBumpConfig,
+	componentName string,
+) (*semverutils.TagFormat, []string, error) {
+	if componentName == "" {
+		return config.CompiledTagFormat(), nil, nil
+	}
+
+	component, err := config.Component(componentName)
+	if err != nil {
+		// Error type could be ErrUnknownComponent
+		return nil, nil, fmt.Errorf("failed to get component: %w", err)
+	}
+
+	tagFormat, err := component.TagFormat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compile tag format of component %s: %w", component.Name, err)
+	}
+
+	log.WithField("component", component.Name).Info("Using component")
+
+	return tagFormat, component.Paths, nil
+}
+
+// discoverComponents adds the components discovered in the workspace manifests at the ref to the config,
+// if the config defines ecosystems to discover. Components defined in the config take precedence.
+func discoverComponents(
+	repository *git.Repository,
+	ref string,
+	config semverutils.CompiledBumpConfig,
+) (semverutils.CompiledBumpConfig, error) {
+	if len(config.Discover) == 0 {
+		return config, nil
+	}
+
+	files, err := gitutils.GetTreeFiles(repository, refOrHead(ref))
+	if err != nil {
+		return semverutils.CompiledBumpConfig{}, fmt.Errorf("failed to get files: %w", err)
+	}
+
+	ecosystems := make([]discovery.Ecosystem, 0, len(config.Discover))
+	for _, ecosystem := range config.Discover {
+		ecosystems = append(ecosystems, discovery.Ecosystem(ecosystem))
+	}
+
+	components, err := discovery.DiscoverComponents(files, ecosystems)
+	if err != nil {
+		// Error type could be ErrUnknownEcosystem or ErrInvalidManifest
+		return semverutils.CompiledBumpConfig{}, fmt.Errorf("failed to discover components: %w", err)
+	}
+
+	config, err = config.WithComponents(components)
+	if err != nil {
+		// Error type could be ErrInvalidComponent
+		return semverutils.CompiledBumpConfig{}, fmt.Errorf("failed to add discovered components: %w", err)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// ErrConfigFileExists is returned when the config file to create already exists.
+	ErrConfigFileExists = errors.New("config file already exists")
+	// ErrUnsupportedConfigFormat is returned when the config file to create is not a YAML file.
+	ErrUnsupportedConfigFormat = errors.New("unsupported config file format")
+)
+
+// ConfigInitOptions holds the options of the config init command.
+type ConfigInitOptions struct {
+	// ConfigPath is the path of the config file to create. If empty, the file is created in the repository directory.
+	ConfigPath string
+	// Force overwrites an existing config file.
+	Force bool
+	// Preset is the name of the preset to base the bumps on. If empty, the default config is used.
+	Preset string
+}
+
+// ConfigOptions holds the options of the config show and config validate commands.
+type ConfigOptions struct {
+	// ConfigPath is the path to the verscout config file. If empty, the config file is searched for.
+	ConfigPath string
+	// ConfigOverrideOptions are only used by the config show command.
+	ConfigOverrideOptions
+}
+
+// NewConfigCmd creates and returns a cobra.Command grouping the commands for managing the config file.
+func NewConfigCmd(repoDirectoryPath *string) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the verscout config file",
+		Long:  "Create, show and validate the verscout config file",
+	}
+
+	configCmd.AddCommand(NewConfigInitCmd(repoDirectoryPath))
+	configCmd.AddCommand(NewConfigShowCmd(repoDirectoryPath))
+	configCmd.AddCommand(NewConfigValidateCmd(repoDirectoryPath))
+
+	return configCmd
+}
+
+// NewConfigInitCmd creates and returns a cobra.Command for creating a commented config file.
+func NewConfigInitCmd(repoDirectoryPath *string) *cobra.Command {
+	var options ConfigInitOptions
+
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a config file",
+		Long:  "Create a config file with the default config or a preset, and a comment describing each option",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := HandleConfigInitCommand(cmd.OutOrStdout(), repoDirectoryPath, options)
+			if err != nil {
+				return fmt.Errorf("error while running config init command: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	initCmd.Flags().
+		StringVarP(
+			&options.ConfigPath,
+			"config-path",
+			"c",
+			"",
+			"The path of the YAML config file to create. Defaults to "+semverutils.ConfigFileNames[0]+
+				" in the repository directory",
+		)
+	initCmd.Flags().BoolVar(&options.Force, "force", false, "Overwrite the config file if it already exists")
+	initCmd.Flags().
+		StringVar(
+			&options.Preset,
+			"preset",
+			"",
+			"The preset to base the bumps on, one of "+strings.Join(semverutils.PresetNames(), ", "),
+		)
+
+	return initCmd
+}
+
+// NewConfigShowCmd creates and returns a cobra.Command for printing the effective config.
+func NewConfigShowCmd(repoDirectoryPath *string) *cobra.Command {
+	var options ConfigOptions
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the effective config",
+		Long:  "Show the effective config, and where the value of each option comes from",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			options.Sources = configOverrideSources(cmd)
+
+			err := HandleConfigShowCommand(cmd.OutOrStdout(), repoDirectoryPath, options)
+			if err != nil {
+				return fmt.Errorf("error while running config show command: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	addConfigPathFlag(showCmd, &options.ConfigPath)
+	addConfigOverrideFlags(showCmd, &options.ConfigOverrideOptions)
+
+	return showCmd
+}
+
+// NewConfigValidateCmd creates and returns a cobra.Command for validating a config file.
+func NewConfigValidateCmd(repoDirectoryPath *string) *cobra.Command {
+	var options ConfigOptions
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the config file",
+		Long:  "Validate the config file without accessing the git repository",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := HandleConfigValidateCommand(cmd.OutOrStdout(), repoDirectoryPath, options)
+			if err != nil {
+				return fmt.Errorf("error while running config validate command: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	addConfigPathFlag(validateCmd, &options.ConfigPath)
+
+	return validateCmd
+}
+
+// HandleConfigInitCommand writes the default config, or the bumps of the preset, with comments to the config file.
+// Returns ErrConfigFileExists if the file exists, unless Force is set,
+// and ErrUnsupportedConfigFormat if the file is not a YAML file.
+func HandleConfigInitCommand(writer io.Writer, repoDirectoryPath *string, options ConfigInitOptions) error {
+	configPath := options.ConfigPath
+	if configPath == "" {
+		configPath = filepath.Join(*repoDirectoryPath, semverutils.ConfigFileNames[0])
+	}
+
+	extension := filepath.Ext(configPath)
+	if extension != ".yaml" && extension != ".yml" {
+		return fmt.Errorf("%w: %s, only YAML config files can be created", ErrUnsupportedConfigFormat, configPath)
+	}
+
+	config := semverutils.DefaultBumpConfig
+
+	if options.Preset != "" {
+		bumps, err := semverutils.PresetBumps(options.Preset)
+		if err != nil {
+			// Error type could be ErrUnknownPreset
+			return fmt.Errorf("failed to get preset: %w", err)
+		}
+
+		config.Preset = options.Preset
+		config.Bumps = bumps
+	}
+
+	content, err := config.CommentedYAML()
+	if err != nil {
+		return fmt.Errorf("failed to render config: %w", err)
+	}
+
+	if !options.Force {
+		_, err = os.Stat(configPath)
+		if err == nil {
+			return fmt.Errorf("%w: %s, use --force to overwrite it", ErrConfigFileExists, configPath)
+		}
+	}
+
+	err = os.WriteFile(configPath, content, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	log.WithField("configFile", configPath).Info("Created config file")
+
+	_, err = fmt.Fprintf(writer, "Created %s\n", configPath)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// HandleConfigShowCommand writes the effective config, with a comment naming the source of each value.
+// Like the latest and next commands, the default config is used if the config file does not exist.
+func HandleConfigShowCommand(writer io.Writer, repoDirectoryPath *string, options ConfigOptions) error {
+	config, err := loadBumpConfig(options.ConfigPath, *repoDirectoryPath)
+	if err != nil {
+		return err
+	}
+
+	config, err = overrideConfig(config, options.ConfigOverrideOptions)
+	if err != nil {
+		return err
+	}
+
+	content, err := config.SourcedYAML()
+	if err != nil {
+		return fmt.Errorf("failed to render config: %w", err)
+	}
+
+	_, err = writer.Write(content)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// HandleConfigValidateCommand validates the config file. If the config path is empty, the config file is searched
+// for in the repository directory and its parents. Unlike the other commands, a missing config file is an error.
+func HandleConfigValidateCommand(writer io.Writer, repoDirectoryPath *string, options ConfigOptions) error {
+	configPath := options.ConfigPath
+	if configPath == "" {
+		foundPath, err := semverutils.FindConfigFile(*repoDirectoryPath)
+		if err != nil {
+			// Error type could be ErrNoConfigFile
+			return fmt.Errorf("failed to find config file: %w", err)
+		}
+
+		configPath = foundPath
+	}
+
+	_, err := semverutils.LoadBumpConfigFromFile(configPath)
+	if err != nil {
+		// Error type could be ErrUnknownConfigKey, ErrInvalidBumpPattern, ErrConfigExtendsCycle or os.ErrNotExist,
+		// already naming the config file and the position of the invalid value
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "%s is valid\n", configPath)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
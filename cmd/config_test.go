@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleConfigInitCommand(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), ".verscout-config.yaml")
+
+	var output bytes.Buffer
+
+	err := HandleConfigInitCommand(&output, &repoDirectoryPath, ConfigInitOptions{ConfigPath: configPath})
+	require.NoError(t, err)
+	assert.Equal(t, "Created "+configPath+"\n", output.String())
+
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Lower the bumps while the major version is 0:\n")
+
+	config, err := semverutils.LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, semverutils.DefaultBumpConfig.Bumps, config.Bumps)
+}
+
+func TestHandleConfigInitCommand_FileExists(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("tagFormat: v{version}\n"), 0o600))
+
+	err := HandleConfigInitCommand(&bytes.Buffer{}, &repoDirectoryPath, ConfigInitOptions{ConfigPath: configPath})
+	require.ErrorIs(t, err, ErrConfigFileExists)
+
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "tagFormat: v{version}\n", string(content))
+}
+
+func TestHandleConfigInitCommand_Force(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("tagFormat: v{version}\n"), 0o600))
+
+	err := HandleConfigInitCommand(
+		&bytes.Buffer{},
+		&repoDirectoryPath,
+		ConfigInitOptions{ConfigPath: configPath, Force: true},
+	)
+	require.NoError(t, err)
+
+	config, err := semverutils.LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, config.TagFormat)
+}
+
+func TestHandleConfigShowCommand(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(
+		t,
+		os.WriteFile(configPath, []byte("tagFormat: v{version}\nbumps:\n  types:\n    feat: minor\n"), 0o600),
+	)
+
+	var output bytes.Buffer
+
+	err := HandleConfigShowCommand(&output, &repoDirectoryPath, ConfigOptions{ConfigPath: configPath})
+	require.NoError(t, err)
+
+	expected := "---\n" +
+		"preset: \"\" # source: default\n" +
+		"bumps: # source: " + configPath + "\n" +
+		"  majorPatterns: []\n" +
+		"  minorPatterns: []\n" +
+		"  patchPatterns: []\n" +
+		"  types:\n" +
+		"    feat: minor\n" +
+		"initialDevelopment: false # source: default\n" +
+		"tagFormat: v{version} # source: " + configPath + "\n" +
+		"tagPattern: \"\" # source: default\n" +
+		"components: [] # source: default\n" +
+		"groups: [] # source: default\n" +
+		"discover: [] # source: default\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleConfigShowCommand_NoConfigFile(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleConfigShowCommand(
+		&output,
+		&repoDirectoryPath,
+		ConfigOptions{ConfigPath: filepath.Join(t.TempDir(), "missing.yaml")},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "bumps: # source: default\n")
+	assert.Contains(t, output.String(), "tagFormat: \"\" # source: default\n")
+}
+
+func TestHandleConfigValidateCommand(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("tagFormat: v{version}\n"), 0o600))
+
+	var output bytes.Buffer
+
+	err := HandleConfigValidateCommand(&output, &repoDirectoryPath, ConfigOptions{ConfigPath: configPath})
+	require.NoError(t, err)
+	assert.Equal(t, configPath+" is valid\n", output.String())
+}
+
+func TestHandleConfigValidateCommand_Invalid(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("tag_format: v{version}\n"), 0o600))
+
+	err := HandleConfigValidateCommand(&bytes.Buffer{}, &repoDirectoryPath, ConfigOptions{ConfigPath: configPath})
+	require.ErrorIs(t, err, semverutils.ErrUnknownConfigKey)
+	assert.EqualError(t, err, configPath+":1:1: unknown config key: tag_format")
+}
+
+func TestHandleConfigValidateCommand_InvalidTOML(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), ".verscout-config.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte("[bumps]\nmajorPatterns = [\"^break(\"]\n"), 0o600))
+
+	err := HandleConfigValidateCommand(&bytes.Buffer{}, &repoDirectoryPath, ConfigOptions{ConfigPath: configPath})
+	require.ErrorIs(t, err, semverutils.ErrInvalidBumpPattern)
+	assert.ErrorContains(t, err, "invalid config file "+configPath+": bumps.majorPatterns[0]: invalid bump pattern")
+	assert.NotContains(t, err.Error(), "invalid config file: ")
+}
+
+func TestHandleConfigValidateCommand_NoConfigFile(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), "missing.yaml")
+
+	err := HandleConfigValidateCommand(&bytes.Buffer{}, &repoDirectoryPath, ConfigOptions{ConfigPath: configPath})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestHandleConfigShowCommand_Preset(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("preset: angular\nbumps:\n  types:\n    docs: patch\n"), 0o600))
+
+	var output bytes.Buffer
+
+	err := HandleConfigShowCommand(&output, &repoDirectoryPath, ConfigOptions{ConfigPath: configPath})
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "preset: angular # source: "+configPath+"\n")
+	assert.Contains(t, output.String(), "bumps: # source: "+configPath+" on top of preset angular\n")
+	assert.Contains(t, output.String(), "    docs: patch\n")
+}
+
+func TestHandleConfigInitCommand_Preset(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), ".verscout-config.yaml")
+
+	err := HandleConfigInitCommand(
+		&bytes.Buffer{},
+		&repoDirectoryPath,
+		ConfigInitOptions{ConfigPath: configPath, Preset: "gitmoji"},
+	)
+	require.NoError(t, err)
+
+	config, err := semverutils.LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "gitmoji", config.Preset)
+
+	presetBumps, err := semverutils.PresetBumps("gitmoji")
+	require.NoError(t, err)
+	assert.Equal(t, presetBumps, config.Bumps)
+}
+
+func TestHandleConfigInitCommand_UnknownPreset(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), ".verscout-config.yaml")
+
+	err := HandleConfigInitCommand(
+		&bytes.Buffer{},
+		&repoDirectoryPath,
+		ConfigInitOptions{ConfigPath: configPath, Preset: "jquery"},
+	)
+	require.ErrorIs(t, err, semverutils.ErrUnknownPreset)
+	assert.NoFileExists(t, configPath)
+}
+
+func TestHandleConfigInitCommand_RepositoryDirectory(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := t.TempDir()
+
+	var output bytes.Buffer
+
+	err := HandleConfigInitCommand(&output, &repoDirectoryPath, ConfigInitOptions{})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(repoDirectoryPath, ".verscout-config.yaml"))
+}
+
+func TestHandleConfigInitCommand_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	configPath := filepath.Join(t.TempDir(), ".verscout-config.json")
+
+	err := HandleConfigInitCommand(&bytes.Buffer{}, &repoDirectoryPath, ConfigInitOptions{ConfigPath: configPath})
+	require.ErrorIs(t, err, ErrUnsupportedConfigFormat)
+	assert.NoFileExists(t, configPath)
+}
+
+func TestHandleConfigShowCommand_DiscoveredConfigFile(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := t.TempDir()
+	configPath := filepath.Join(repoDirectoryPath, ".verscout-config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("tagFormat: v{version}\n"), 0o600))
+
+	var output bytes.Buffer
+
+	err := HandleConfigShowCommand(&output, &repoDirectoryPath, ConfigOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "tagFormat: v{version} # source: "+configPath+"\n")
+}
+
+func TestHandleConfigValidateCommand_DiscoveredConfigFile(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	configPath := filepath.Join(directory, "pyproject.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte("[tool.verscout]\npreset = \"eslint\"\n"), 0o600))
+
+	repoDirectoryPath := filepath.Join(directory, "service")
+	require.NoError(t, os.MkdirAll(repoDirectoryPath, 0o750))
+
+	var output bytes.Buffer
+
+	err := HandleConfigValidateCommand(&output, &repoDirectoryPath, ConfigOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, configPath+" is valid\n", output.String())
+}
+
+func TestHandleConfigShowCommand_BumpPatternFlags(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleConfigShowCommand(
+		&output,
+		&repoDirectoryPath,
+		ConfigOptions{
+			ConfigPath: filepath.Join(t.TempDir(), "missing.yaml"),
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				MinorPatterns: []string{"^feature:"},
+				Sources:       map[string]string{"bumps.minorPatterns": "--minor-pattern"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "bumps: # source: --minor-pattern on top of default\n")
+	assert.Contains(t, output.String(), "  minorPatterns:\n    - '^feature:'\n")
+}
+
+func TestHandleConfigShowCommand_TagFormatFlag(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleConfigShowCommand(
+		&output,
+		&repoDirectoryPath,
+		ConfigOptions{
+			ConfigPath: filepath.Join(t.TempDir(), "missing.yaml"),
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				TagFormat: "release-{version}",
+				Sources:   map[string]string{"tagFormat": "VERSCOUT_TAG_FORMAT"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "tagFormat: release-{version} # source: VERSCOUT_TAG_FORMAT\n")
+}
+
+func TestNewConfigInitCmd_ConfigPathFlag(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	flag := NewConfigInitCmd(&repoDirectoryPath).Flags().Lookup("config-path")
+	require.NotNil(t, flag)
+	assert.Contains(t, flag.Usage, "Defaults to .verscout-config.yaml in the repository directory")
+	assert.NotContains(t, flag.Usage, "searched")
+}
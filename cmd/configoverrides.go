@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/spf13/cobra"
+)
+
+// ConfigOverrideOptions holds the config values given by flags or environment variables.
+// They are applied on top of the config file, see semverutils.CompiledBumpConfig.WithOverrides.
+// Components, groups and the discovery of components can only be configured in the config file.
+type ConfigOverrideOptions struct {
+	// Preset replaces the bumps of the config file with the bumps of the preset.
+	Preset string
+	// MajorPatterns are the patterns of commit messages causing a major bump.
+	MajorPatterns []string
+	// MinorPatterns are the patterns of commit messages causing a minor bump.
+	MinorPatterns []string
+	// PatchPatterns are the patterns of commit messages causing a patch bump.
+	PatchPatterns []string
+	// Types are the type rules in the format TYPE=BUMP, e.g. "feat=minor" or "feat(internal)=patch".
+	Types []string
+	// Breaking is the bump type of breaking changes, one of none, patch, minor or major.
+	Breaking string
+	// InitialDevelopment lowers the bumps while the major version is 0.
+	InitialDevelopment bool
+	// TagFormat is the template for the version tags, e.g. "release-{version}".
+	TagFormat string
+	// TagPattern is the regex for the version tags, e.g. `^api/v(?P<version>.+)$`.
+	TagPattern string
+	// Sources maps the config keys of the given values, e.g. "bumps.majorPatterns", to the flag or
+	// environment variable giving them, e.g. "--major-pattern". Values without a source are not given.
+	Sources map[string]string
+}
+
+// configOverrideFlags are the flags of the config values and the config keys they override.
+var configOverrideFlags = []struct {
+	name      string
+	configKey string
+}{
+	{name: "preset", configKey: "preset"},
+	{name: "major-pattern", configKey: "bumps.majorPatterns"},
+	{name: "minor-pattern", configKey: "bumps.minorPatterns"},
+	{name: "patch-pattern", configKey: "bumps.patchPatterns"},
+	{name: "type", configKey: "bumps.types"},
+	{name: "breaking", configKey: "bumps.breaking"},
+	{name: "initial-development", configKey: "initialDevelopment"},
+	{name: "tag-format", configKey: "tagFormat"},
+	{name: "tag-pattern", configKey: "tagPattern"},
+}
+
+// addConfigOverrideFlags adds the flags for all config values that can be given outside of the config file.
+func addConfigOverrideFlags(cmd *cobra.Command, options *ConfigOverrideOptions) {
+	cmd.Flags().
+		StringVar(
+			&options.Preset,
+			"preset",
+			"",
+			"The preset replacing the bumps of the config file, one of "+
+				strings.Join(semverutils.PresetNames(), ", "),
+		)
+	cmd.Flags().
+		StringArrayVar(
+			&options.MajorPatterns,
+			"major-pattern",
+			nil,
+			"A pattern of commit messages causing a major bump. Can be repeated, replaces the patterns of the config",
+		)
+	cmd.Flags().
+		StringArrayVar(
+			&options.MinorPatterns,
+			"minor-pattern",
+			nil,
+			"A pattern of commit messages causing a minor bump. Can be repeated, replaces the patterns of the config",
+		)
+	cmd.Flags().
+		StringArrayVar(
+			&options.PatchPatterns,
+			"patch-pattern",
+			nil,
+			"A pattern of commit messages causing a patch bump. Can be repeated, replaces the patterns of the config",
+		)
+	cmd.Flags().
+		StringArrayVar(
+			&options.Types,
+			"type",
+			nil,
+			"A type rule in the format TYPE=BUMP, e.g. feat=minor. Can be repeated, overrides the rule of the config",
+		)
+	cmd.Flags().
+		StringVar(
+			&options.Breaking,
+			"breaking",
+			"",
+			"The bump of breaking changes, one of none, patch, minor or major",
+		)
+	cmd.Flags().
+		BoolVar(
+			&options.InitialDevelopment,
+			"initial-development",
+			false,
+			"Lower the bumps while the major version is 0",
+		)
+	addTagFormatFlags(cmd, options)
+}
+
+// addTagFormatFlags adds the flags for the tag format and the tag pattern.
+func addTagFormatFlags(cmd *cobra.Command, options *ConfigOverrideOptions) {
+	cmd.Flags().
+		StringVar(
+			&options.TagFormat,
+			"tag-format",
+			"",
+			"The template for the version tags, containing the placeholder {version}, e.g. release-{version}",
+		)
+	cmd.Flags().
+		StringVar(
+			&options.TagPattern,
+			"tag-pattern",
+			"",
+			"The regex for the version tags, with a named group version, e.g. ^api/v(?P<version>.+)$",
+		)
+}
+
+// configOverrideSources returns the sources of the config override flags that are set, see ConfigOverrideOptions.
+func configOverrideSources(cmd *cobra.Command) map[string]string {
+	sources := make(map[string]string)
+
+	for _, overrideFlag := range configOverrideFlags {
+		flag := cmd.Flags().Lookup(overrideFlag.name)
+		if flag != nil && flag.Changed {
+			sources[overrideFlag.configKey] = flagSource(flag)
+		}
+	}
+
+	return sources
+}
+
+// overrideConfig applies the given config values on top of the config, see ConfigOverrideOptions.
+// Returns semverutils.ErrInvalidTypeRule or semverutils.ErrInvalidBumpType if a type rule or the breaking bump
+// is invalid.
+func overrideConfig(
+	config semverutils.CompiledBumpConfig,
+	options ConfigOverrideOptions,
+) (semverutils.CompiledBumpConfig, error) {
+	if len(options.Sources) == 0 {
+		return config, nil
+	}
+
+	overrides := semverutils.ConfigOverrides{
+		Preset: options.Preset,
+		Bumps: semverutils.BumpPatterns{
+			MajorPatterns: options.MajorPatterns,
+			MinorPatterns: options.MinorPatterns,
+			PatchPatterns: options.PatchPatterns,
+		},
+		InitialDevelopment: options.InitialDevelopment,
+		TagFormat:          options.TagFormat,
+		TagPattern:         options.TagPattern,
+		Sources:            options.Sources,
+	}
+
+	if len(options.Types) > 0 {
+		overrides.Bumps.Types = make(map[string]semverutils.BumpType, len(options.Types))
+
+		for _, rule := range options.Types {
+			commitType, bump, found := strings.Cut(rule, "=")
+			if !found {
+				return semverutils.CompiledBumpConfig{}, fmt.Errorf(
+					"invalid %s: %w: %q, expected TYPE=BUMP",
+					options.Sources["bumps.types"],
+					semverutils.ErrInvalidTypeRule,
+					rule,
+				)
+			}
+
+			bumpType, err := semverutils.ParseBumpType(strings.TrimSpace(bump))
+			if err != nil {
+				// Error type could be ErrInvalidBumpType
+				return semverutils.CompiledBumpConfig{}, fmt.Errorf(
+					"invalid %s: %w",
+					options.Sources["bumps.types"],
+					err,
+				)
+			}
+
+			overrides.Bumps.Types[strings.TrimSpace(commitType)] = bumpType
+		}
+	}
+
+	if options.Breaking != "" {
+		bumpType, err := semverutils.ParseBumpType(options.Breaking)
+		if err != nil {
+			// Error type could be ErrInvalidBumpType
+			return semverutils.CompiledBumpConfig{}, fmt.Errorf(
+				"invalid %s: %w",
+				options.Sources["bumps.breaking"],
+				err,
+			)
+		}
+
+		overrides.Bumps.Breaking = bumpType
+	}
+
+	// Error type could be ErrUnknownPreset, ErrInvalidBumpPattern, ErrInvalidTypeRule or ErrInvalidTagFormat,
+	// already prefixed with the source of the invalid value
+	return config.WithOverrides(overrides)
+}
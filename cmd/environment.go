@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+// EnvironmentVariablePrefix is the prefix of the environment variables setting flags,
+// e.g. VERSCOUT_FIRST_VERSION sets --first-version.
+const EnvironmentVariablePrefix = "VERSCOUT_"
+
+const (
+	// environmentVariableAnnotation is the flag annotation holding the environment variable that set the flag.
+	environmentVariableAnnotation = "verscout_environment_variable"
+	// mutuallyExclusiveAnnotation is the flag annotation cobra uses for groups of mutually exclusive flags,
+	// each group as the names of its flags separated by spaces.
+	mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+)
+
+// environmentVariableName returns the name of the environment variable setting the flag,
+// e.g. VERSCOUT_FIRST_VERSION for first-version.
+func environmentVariableName(flagName string) string {
+	return EnvironmentVariablePrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// applyEnvironmentVariables sets the flags not given on the command line from their environment variables,
+// see environmentVariableName. Flags taking a list of values are set from the lines of the environment variable.
+// This makes the precedence flags, environment variables, config file, defaults.
+// The environment variable of a flag is ignored if a mutually exclusive flag is given on the command line.
+func applyEnvironmentVariables(flags *pflag.FlagSet, lookupEnv func(string) (string, bool)) error {
+	commandLineFlags := make(map[string]bool)
+
+	flags.Visit(func(flag *pflag.Flag) {
+		commandLineFlags[flag.Name] = true
+	})
+
+	var err error
+
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" || flag.Name == "version" {
+			return
+		}
+
+		name := environmentVariableName(flag.Name)
+
+		value, found := lookupEnv(name)
+		if !found {
+			return
+		}
+
+		if excludingFlag, excluded := excludedByCommandLine(flag, commandLineFlags); excluded {
+			log.WithFields(log.Fields{"environmentVariable": name, "flag": excludingFlag}).
+				Info("Ignoring environment variable of a flag that cannot be combined with a given flag")
+
+			return
+		}
+
+		err = setFlagFromEnvironmentVariable(flags, flag, name, value)
+	})
+
+	return err
+}
+
+// excludedByCommandLine returns a flag given on the command line that cannot be combined with the flag,
+// see cobra.Command.MarkFlagsMutuallyExclusive.
+func excludedByCommandLine(flag *pflag.Flag, commandLineFlags map[string]bool) (string, bool) {
+	for _, group := range flag.Annotations[mutuallyExclusiveAnnotation] {
+		for _, name := range strings.Fields(group) {
+			if name != flag.Name && commandLineFlags[name] {
+				return name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// setFlagFromEnvironmentVariable sets the flag to the value of the environment variable, and records
+// the environment variable as the source of the flag, see flagSource.
+func setFlagFromEnvironmentVariable(flags *pflag.FlagSet, flag *pflag.Flag, name string, value string) error {
+	sliceValue, isSlice := flag.Value.(pflag.SliceValue)
+	if isSlice {
+		var values []string
+
+		for _, line := range strings.Split(value, "\n") {
+			if strings.TrimSpace(line) != "" {
+				values = append(values, line)
+			}
+		}
+
+		err := sliceValue.Replace(values)
+		if err != nil {
+			return fmt.Errorf("invalid value of %s: %w", name, err)
+		}
+
+		flag.Changed = true
+	} else {
+		err := flags.Set(flag.Name, value)
+		if err != nil {
+			return fmt.Errorf("invalid value of %s: %w", name, err)
+		}
+	}
+
+	err := flags.SetAnnotation(flag.Name, environmentVariableAnnotation, []string{name})
+	if err != nil {
+		return fmt.Errorf("failed to annotate flag %s: %w", flag.Name, err)
+	}
+
+	return nil
+}
+
+// flagSource returns the environment variable that set the flag, or the flag itself, e.g. "--major-pattern".
+func flagSource(flag *pflag.Flag) string {
+	environmentVariables := flag.Annotations[environmentVariableAnnotation]
+	if len(environmentVariables) > 0 {
+		return environmentVariables[0]
+	}
+
+	return "--" + flag.Name
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/erNail/verscout/internal/gitutils"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lookupTestEnv(environment map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, found := environment[name]
+
+		return value, found
+	}
+}
+
+func TestApplyEnvironmentVariables(t *testing.T) {
+	t.Parallel()
+
+	var firstVersion string
+
+	flags := pflag.NewFlagSet("next", pflag.ContinueOnError)
+	flags.StringVar(&firstVersion, "first-version", "1.0.0", "")
+	require.NoError(t, flags.Parse(nil))
+
+	err := applyEnvironmentVariables(flags, lookupTestEnv(map[string]string{"VERSCOUT_FIRST_VERSION": "0.1.0"}))
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", firstVersion)
+	assert.Equal(t, "VERSCOUT_FIRST_VERSION", flagSource(flags.Lookup("first-version")))
+}
+
+func TestApplyEnvironmentVariables_FlagTakesPrecedence(t *testing.T) {
+	t.Parallel()
+
+	var firstVersion string
+
+	flags := pflag.NewFlagSet("next", pflag.ContinueOnError)
+	flags.StringVar(&firstVersion, "first-version", "1.0.0", "")
+	require.NoError(t, flags.Parse([]string{"--first-version", "2.0.0"}))
+
+	err := applyEnvironmentVariables(flags, lookupTestEnv(map[string]string{"VERSCOUT_FIRST_VERSION": "0.1.0"}))
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", firstVersion)
+	assert.Equal(t, "--first-version", flagSource(flags.Lookup("first-version")))
+}
+
+func TestApplyEnvironmentVariables_Lines(t *testing.T) {
+	t.Parallel()
+
+	var majorPatterns []string
+
+	flags := pflag.NewFlagSet("next", pflag.ContinueOnError)
+	flags.StringArrayVar(&majorPatterns, "major-pattern", nil, "")
+	require.NoError(t, flags.Parse(nil))
+
+	err := applyEnvironmentVariables(
+		flags,
+		lookupTestEnv(map[string]string{"VERSCOUT_MAJOR_PATTERN": "^breaking:\n^.{1,3}!:\n"}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"^breaking:", "^.{1,3}!:"}, majorPatterns)
+	assert.True(t, flags.Changed("major-pattern"))
+}
+
+func TestApplyEnvironmentVariables_InvalidValue(t *testing.T) {
+	t.Parallel()
+
+	var exitCode int
+
+	flags := pflag.NewFlagSet("next", pflag.ContinueOnError)
+	flags.IntVar(&exitCode, "exit-code", 0, "")
+	require.NoError(t, flags.Parse(nil))
+
+	err := applyEnvironmentVariables(flags, lookupTestEnv(map[string]string{"VERSCOUT_EXIT_CODE": "one"}))
+	require.ErrorContains(t, err, "invalid value of VERSCOUT_EXIT_CODE")
+}
+
+func TestApplyEnvironmentVariables_SetVersionExcludesBump(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	ref := "HEAD"
+
+	cmd := NewNextCmd(&gitutils.MockGit{}, &repoDirectoryPath, &ref)
+	require.NoError(t, cmd.ParseFlags([]string{"--set-version", "3.0.0"}))
+
+	err := applyEnvironmentVariables(cmd.Flags(), lookupTestEnv(map[string]string{
+		"VERSCOUT_BUMP":          "minor",
+		"VERSCOUT_FIRST_VERSION": "0.1.0",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, cmd.ValidateFlagGroups())
+	assert.False(t, cmd.Flags().Changed("bump"))
+	assert.Equal(t, "VERSCOUT_FIRST_VERSION", flagSource(cmd.Flags().Lookup("first-version")))
+}
+
+func TestApplyEnvironmentVariables_PromoteExcludesPreRelease(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	ref := "HEAD"
+
+	cmd := NewNextCmd(&gitutils.MockGit{}, &repoDirectoryPath, &ref)
+	require.NoError(t, cmd.ParseFlags([]string{"--promote"}))
+
+	err := applyEnvironmentVariables(cmd.Flags(), lookupTestEnv(map[string]string{"VERSCOUT_PRERELEASE": "rc"}))
+	require.NoError(t, err)
+	require.NoError(t, cmd.ValidateFlagGroups())
+	assert.False(t, cmd.Flags().Changed("prerelease"))
+}
+
+func TestApplyEnvironmentVariables_ComponentExcludesAll(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+	ref := "HEAD"
+
+	cmd := NewNextCmd(&gitutils.MockGit{}, &repoDirectoryPath, &ref)
+	require.NoError(t, cmd.ParseFlags([]string{"--component", "billing"}))
+
+	err := applyEnvironmentVariables(cmd.Flags(), lookupTestEnv(map[string]string{"VERSCOUT_ALL": "true"}))
+	require.NoError(t, err)
+	require.NoError(t, cmd.ValidateFlagGroups())
+	assert.False(t, cmd.Flags().Changed("all"))
+}
@@ -4,22 +4,45 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/erNail/verscout/internal/gitutils"
+	"github.com/erNail/verscout/internal/semverutils"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// LatestOptions holds the options of the latest command.
+type LatestOptions struct {
+	// NoLatestVersionExitCode is the exit code to use when no latest version is found.
+	NoLatestVersionExitCode int
+	// ConfigPath is the path to the verscout config file, which defines the tag format.
+	ConfigPath string
+	// SelectionStrategy defines how the latest version tag is selected.
+	SelectionStrategy gitutils.TagSelectionStrategy
+	// Ref is the branch, tag or commit to find the latest version tag for. Defaults to HEAD.
+	Ref string
+	// Component is the name of the monorepo component to find the latest version tag for.
+	// If empty, the tag format of the config is used.
+	Component string
+	// ConfigOverrideOptions are the tag format and the tag pattern applied on top of the config file.
+	ConfigOverrideOptions
+}
+
 // NewLatestCmd creates and returns a cobra.Command for retrieving the latest version tag.
-func NewLatestCmd(git GitInterface, repoDirectoryPath *string) *cobra.Command {
-	var noLatestVersionExitCode int
+// The latest version tag is searched among the tags reachable from the given ref.
+func NewLatestCmd(git GitInterface, repoDirectoryPath *string, ref *string) *cobra.Command {
+	var options LatestOptions
 
 	latestCmd := &cobra.Command{
 		Use:   "latest",
 		Short: "Scout the latest version tag",
 		Long:  "Scout the latest version tag in the format MAJOR.MINOR.PATCH",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			err := HandleLatestCommand(cmd.OutOrStdout(), git, repoDirectoryPath, noLatestVersionExitCode)
+			options.Ref = *ref
+			options.Sources = configOverrideSources(cmd)
+
+			err := HandleLatestCommand(cmd.OutOrStdout(), git, repoDirectoryPath, options)
 			if err != nil {
 				return fmt.Errorf("error while running latest command: %w", err)
 			}
@@ -29,7 +52,17 @@ func NewLatestCmd(git GitInterface, repoDirectoryPath *string) *cobra.Command {
 	}
 
 	latestCmd.Flags().
-		IntVarP(&noLatestVersionExitCode, "exit-code", "e", 0, "The exit code to use when no latest version is found")
+		IntVarP(
+			&options.NoLatestVersionExitCode,
+			"exit-code",
+			"e",
+			0,
+			"The exit code to use when no latest version is found",
+		)
+	addConfigPathFlag(latestCmd, &options.ConfigPath)
+	addTagFormatFlags(latestCmd, &options.ConfigOverrideOptions)
+	addSelectionStrategyFlag(latestCmd, &options.SelectionStrategy)
+	addComponentFlag(latestCmd, &options.Component)
 
 	return latestCmd
 }
@@ -39,18 +72,42 @@ func HandleLatestCommand(
 	writer io.Writer,
 	git GitInterface,
 	repoDirectoryPath *string,
-	noLatestVersionExitCode int,
+	options LatestOptions,
 ) error {
+	config, err := loadBumpConfig(options.ConfigPath, *repoDirectoryPath)
+	if err != nil {
+		return err
+	}
+
+	config, err = overrideConfig(config, options.ConfigOverrideOptions)
+	if err != nil {
+		return err
+	}
+
 	repository, err := git.PlainOpen(*repoDirectoryPath)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
 
-	semVer, err := gitutils.GetLatestVersion(repository)
+	config, err = discoverComponents(repository, options.Ref, config)
+	if err != nil {
+		return err
+	}
+
+	tagFormat, _, err := resolveComponent(config, options.Component)
+	if err != nil {
+		return err
+	}
+
+	semVer, err := gitutils.GetLatestVersion(repository, gitutils.LatestVersionTagOptions{
+		Strategy:      options.SelectionStrategy,
+		TagFormat:     tagFormat,
+		ReachableFrom: refOrHead(options.Ref),
+	})
 	if err != nil {
 		if errors.Is(err, gitutils.ErrNoTags) || errors.Is(err, gitutils.ErrNoValidVersionTags) {
-			if noLatestVersionExitCode != 0 {
-				return &ExitError{Code: noLatestVersionExitCode, Err: err}
+			if options.NoLatestVersionExitCode != 0 {
+				return &ExitError{Code: options.NoLatestVersionExitCode, Err: err}
 			}
 
 			log.Warnf("Latest version not found: %v", err)
@@ -70,3 +127,89 @@ func HandleLatestCommand(
 
 	return nil
 }
+
+// addConfigPathFlag adds the flag for the path to the verscout config file.
+func addConfigPathFlag(cmd *cobra.Command, configPath *string) {
+	cmd.Flags().
+		StringVarP(
+			configPath,
+			"config-path",
+			"c",
+			"",
+			"The path to the verscout config file. If empty, the config file is searched for in the repository "+
+				"directory and its parent directories",
+		)
+}
+
+// loadBumpConfig loads and compiles the verscout config file.
+// If the config path is empty, the config file is searched for in the repository directory and its parents.
+// The default config is used if no config file is found, or if the config file does not exist.
+func loadBumpConfig(configPath string, repoDirectoryPath string) (semverutils.CompiledBumpConfig, error) {
+	if configPath == "" {
+		foundPath, err := semverutils.FindConfigFile(repoDirectoryPath)
+		if err != nil {
+			if !errors.Is(err, semverutils.ErrNoConfigFile) {
+				return semverutils.CompiledBumpConfig{}, fmt.Errorf("failed to find config file: %w", err)
+			}
+
+			log.Infof("Failed to find config file: %v", err)
+			log.Info("Using default config")
+
+			return compileDefaultBumpConfig()
+		}
+
+		configPath = foundPath
+	}
+
+	config, err := semverutils.LoadBumpConfigFromFile(configPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return semverutils.CompiledBumpConfig{}, fmt.Errorf("failed to load config file: %w", err)
+		}
+
+		log.Infof("Failed to load config file: %v", err)
+		log.Info("Using default config")
+
+		return compileDefaultBumpConfig()
+	}
+
+	log.WithField("configFile", configPath).Info("Using config file")
+
+	return config, nil
+}
+
+// compileDefaultBumpConfig compiles the default config.
+func compileDefaultBumpConfig() (semverutils.CompiledBumpConfig, error) {
+	config, err := semverutils.DefaultBumpConfig.Compile()
+	if err != nil {
+		return semverutils.CompiledBumpConfig{}, fmt.Errorf("failed to compile default config: %w", err)
+	}
+
+	return config, nil
+}
+
+// addSelectionStrategyFlag adds the flag for choosing how the latest version tag is selected.
+func addSelectionStrategyFlag(cmd *cobra.Command, strategy *gitutils.TagSelectionStrategy) {
+	cmd.Flags().
+		StringVarP(
+			(*string)(strategy),
+			"selection-strategy",
+			"s",
+			string(gitutils.SemVerPrecedence),
+			fmt.Sprintf(
+				"How to select the latest version tag. Either '%s' for the highest SemVer precedence, "+
+					"or '%s' for the newest commit",
+				gitutils.SemVerPrecedence,
+				gitutils.CommitTime,
+			),
+		)
+}
+
+// refOrHead returns the given ref, or HEAD if it is empty.
+func refOrHead(ref string) string {
+	if ref == "" {
+		return "HEAD"
+	}
+
+	return ref
+}
@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/erNail/verscout/internal/discovery"
 	"github.com/erNail/verscout/internal/gitutils"
+	"github.com/erNail/verscout/internal/semverutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +28,12 @@ func TestHandleLatestCommand_ValidTag(t *testing.T) {
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 0)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 0},
+	)
 	require.NoError(t, err)
 
 	assert.Equal(t, "1.0.0\n", output.String())
@@ -44,12 +53,221 @@ func TestHandleLatestCommand_ValidTagWithVPrefix(t *testing.T) {
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 0)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 0},
+	)
 	require.NoError(t, err)
 
 	assert.Equal(t, "1.0.0\n", output.String())
 }
 
+func TestHandleLatestCommand_PreReleaseTagWithBuildMetadata(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.4.0-rc.1+build.7", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 0},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.4.0-rc.1+build.7\n", output.String())
+}
+
+func TestHandleLatestCommand_SelectionStrategy(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "2.0.0", commitHash)
+	require.NoError(t, err)
+	commitHash, err = gitutils.CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.9.1", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{SelectionStrategy: gitutils.SemVerPrecedence},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0\n", output.String())
+
+	output.Reset()
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{SelectionStrategy: gitutils.CommitTime},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.9.1\n", output.String())
+}
+
+func TestHandleLatestCommand_InvalidSelectionStrategy(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 2, SelectionStrategy: "newest"},
+	)
+	require.ErrorIs(t, err, gitutils.ErrInvalidSelectionStrategy)
+
+	var exitErr *ExitError
+
+	require.NotErrorAs(t, err, &exitErr)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleLatestCommand_IgnoresTagsNotReachableFromHead(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	baseHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", baseHash)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutNewBranch(repo, "release-2", baseHash))
+	commitHash, err := gitutils.CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "2.0.0", commitHash)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutBranch(repo, "master"))
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, LatestOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.0\n", output.String())
+}
+
+func TestHandleLatestCommand_Ref(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	baseHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", baseHash)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutNewBranch(repo, "release-2", baseHash))
+	releaseHash, err := gitutils.CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateAnnotatedTag(repo, "2.0.0", releaseHash, "Annotated tag")
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutBranch(repo, "master"))
+
+	repoDirectoryPath := "."
+
+	for ref, expected := range map[string]string{
+		"":                   "1.0.0\n",
+		"HEAD":               "1.0.0\n",
+		"master":             "1.0.0\n",
+		"release-2":          "2.0.0\n",
+		"2.0.0":              "2.0.0\n",
+		releaseHash.String(): "2.0.0\n",
+		"release-2~1":        "1.0.0\n",
+	} {
+		var output bytes.Buffer
+
+		err = HandleLatestCommand(
+			&output,
+			&gitutils.MockGit{Repo: repo},
+			&repoDirectoryPath,
+			LatestOptions{Ref: ref},
+		)
+		require.NoError(t, err, ref)
+		assert.Equal(t, expected, output.String(), ref)
+	}
+}
+
+func TestHandleLatestCommand_InvalidRef(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 2, Ref: "does-not-exist"},
+	)
+	require.Error(t, err)
+
+	var exitErr *ExitError
+
+	require.NotErrorAs(t, err, &exitErr)
+	assert.Empty(t, output.String())
+}
+
 func TestHandleLatestCommand_InvalidTag(t *testing.T) {
 	t.Parallel()
 
@@ -64,7 +282,12 @@ func TestHandleLatestCommand_InvalidTag(t *testing.T) {
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 0)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 0},
+	)
 	require.NoError(t, err)
 
 	assert.Empty(t, output.String())
@@ -82,7 +305,12 @@ func TestHandleLatestCommand_NoTag(t *testing.T) {
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 0)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 0},
+	)
 	require.NoError(t, err)
 
 	assert.Empty(t, output.String())
@@ -102,7 +330,12 @@ func TestHandleLatestCommand_AnnotatedTag(t *testing.T) {
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 0)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 0},
+	)
 	require.NoError(t, err)
 
 	assert.Equal(t, "1.0.0\n", output.String())
@@ -120,7 +353,12 @@ func TestHandleLatestCommand_NoLatestVersionExitCode_NoExistingTags(t *testing.T
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 2)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 2},
+	)
 	require.Error(t, err)
 
 	var exitErr *ExitError
@@ -143,7 +381,12 @@ func TestHandleLatestCommand_NoLatestVersionExitCode_NoValidVersion(t *testing.T
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 2)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 2},
+	)
 	require.Error(t, err)
 
 	var exitErr *ExitError
@@ -166,7 +409,12 @@ func TestHandleLatestCommand_NoLatestVersionExitCode_ExistingTag(t *testing.T) {
 
 	var output bytes.Buffer
 
-	err = HandleLatestCommand(&output, &gitutils.MockGit{Repo: repo}, &repoDirectoryPath, 2)
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{NoLatestVersionExitCode: 2},
+	)
 	require.NoError(t, err)
 
 	assert.Equal(t, "1.0.0\n", output.String())
@@ -181,8 +429,186 @@ func TestNewLatestCommand(t *testing.T) {
 	require.NoError(t, err)
 
 	repoDirectoryPath := "."
+	ref := "HEAD"
 
-	cmd := NewLatestCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath)
+	cmd := NewLatestCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath, &ref)
 	err = cmd.Execute()
 	require.NoError(t, err)
 }
+
+func TestHandleLatestCommand_TagFormat(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "release-1.2.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v2.0.0", commitHash)
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`tagFormat: "release-{version}"`), 0o600))
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{ConfigPath: configPath},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.0\n", output.String())
+}
+
+func TestHandleLatestCommand_TagFormatFlag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "release-1.2.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v2.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{
+			ConfigPath: ".verscout-config.yaml",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				TagFormat: "release-{version}",
+				Sources:   map[string]string{"tagFormat": "--tag-format"},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.0\n", output.String())
+}
+
+func TestHandleLatestCommand_InvalidTagFormatFlag(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	err := HandleLatestCommand(
+		&bytes.Buffer{},
+		&gitutils.MockGit{},
+		&repoDirectoryPath,
+		LatestOptions{
+			ConfigPath: ".verscout-config.yaml",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				TagFormat: "release",
+				Sources:   map[string]string{"tagFormat": "VERSCOUT_TAG_FORMAT"},
+			},
+		},
+	)
+	require.ErrorIs(t, err, semverutils.ErrInvalidTagFormat)
+	assert.ErrorContains(t, err, "invalid VERSCOUT_TAG_FORMAT: tagFormat:")
+}
+
+func TestHandleLatestCommand_Component(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v1.2.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "shipping/v2.0.0", commitHash)
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(componentConfig), 0o600))
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{ConfigPath: configPath, Component: "billing"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.0\n", output.String())
+}
+
+func TestHandleLatestCommand_DiscoveredGoModule(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "First commit", "go.mod", "module example.com/acme", time.Now())
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(
+		repo,
+		"feat: auth",
+		"libs/auth/go.mod",
+		"module example.com/acme/libs/auth",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v3.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "libs/auth/v1.4.0", commitHash)
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("discover:\n  - go\n"), 0o600))
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{ConfigPath: configPath, Component: "libs/auth"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.4.0\n", output.String())
+}
+
+func TestHandleLatestCommand_DiscoverUnknownEcosystem(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("discover:\n  - maven\n"), 0o600))
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleLatestCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		LatestOptions{ConfigPath: configPath},
+	)
+	require.ErrorIs(t, err, discovery.ErrUnknownEcosystem)
+	assert.Empty(t, output.String())
+}
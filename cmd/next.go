@@ -5,37 +5,68 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/erNail/verscout/internal/gitutils"
 	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/go-git/go-git/v5"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// NextOptions holds the options of the next command.
+type NextOptions struct {
+	// NoNextVersionExitCode is the exit code to use when no next version is found.
+	NoNextVersionExitCode int
+	// ConfigPath is the path to the verscout config file.
+	ConfigPath string
+	// FirstVersion is the version to use if no previous version tags exist.
+	FirstVersion string
+	// PreReleaseChannel is the pre-release channel to calculate the next version for, e.g. "rc".
+	// If empty, the next release version is calculated.
+	PreReleaseChannel string
+	// SelectionStrategy defines how the latest version tag is selected.
+	SelectionStrategy gitutils.TagSelectionStrategy
+	// FirstParent only takes the first parent of merge commits into account when collecting commits.
+	FirstParent bool
+	// Ref is the branch, tag or commit to calculate the next version for. Defaults to HEAD.
+	Ref string
+	// Promote turns the latest pre-release version into its release version, without applying any bump.
+	Promote bool
+	// Graduate turns a latest version 0.y.z into 1.0.0, ending the initial development.
+	Graduate bool
+	// Bump is the bump type to apply instead of determining it from the commits, e.g. "major".
+	// If empty, the bump type is determined from the commits.
+	Bump string
+	// SetVersion is the next version to use, if it is greater than the latest version, e.g. "2.0.0".
+	SetVersion string
+	// Component is the name of the monorepo component to calculate the next version for.
+	// Only commits changing the files of the component are taken into account.
+	// If empty, the tag format of the config is used, and all commits are taken into account.
+	Component string
+	// All calculates the latest and the next version of every component defined in the config.
+	All bool
+	// Output is the output format of the versions of all components. Only used together with All.
+	Output OutputFormat
+	// ConfigOverrideOptions are the config values applied on top of the config file.
+	ConfigOverrideOptions
+}
+
 // NewNextCmd creates and returns a cobra.Command for calculating the next semantic version.
 // It uses git operations to find the latest version tag and analyzes commit messages to
 // determine the next version according to semantic versioning rules.
-func NewNextCmd(git GitInterface, repoDirectoryPath *string) *cobra.Command {
-	var noNextVersionExitCode int
-
-	var configPath string
-
-	var firstVersion string
+// The next version is calculated as if the given ref were checked out.
+func NewNextCmd(git GitInterface, repoDirectoryPath *string, ref *string) *cobra.Command {
+	var options NextOptions
 
 	nextCmd := &cobra.Command{
 		Use:   "next",
 		Short: "Calculate the next version",
-		Long:  "Calculate the next version in the format MAJOR.MINOR.PATCH",
+		Long:  "Calculate the next version in the format MAJOR.MINOR.PATCH[-CHANNEL.N]",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			err := HandleNextCommand(
-				cmd.OutOrStdout(),
-				git,
-				repoDirectoryPath,
-				noNextVersionExitCode,
-				configPath,
-				firstVersion,
-			)
+			options.Ref = *ref
+			options.Sources = configOverrideSources(cmd)
+
+			err := HandleNextCommand(cmd.OutOrStdout(), git, repoDirectoryPath, options)
 			if err != nil {
 				return fmt.Errorf("error while running next command: %w", err)
 			}
@@ -45,11 +76,88 @@ func NewNextCmd(git GitInterface, repoDirectoryPath *string) *cobra.Command {
 	}
 
 	nextCmd.Flags().
-		IntVarP(&noNextVersionExitCode, "exit-code", "e", 0, "The exit code to use when no next version is found")
+		IntVarP(
+			&options.NoNextVersionExitCode,
+			"exit-code",
+			"e",
+			0,
+			"The exit code to use when no next version is found",
+		)
+	addConfigPathFlag(nextCmd, &options.ConfigPath)
+	addConfigOverrideFlags(nextCmd, &options.ConfigOverrideOptions)
+	nextCmd.Flags().
+		StringVarP(
+			&options.FirstVersion,
+			"first-version",
+			"f",
+			"1.0.0",
+			"The first version to use if no previous version tags exist",
+		)
+	nextCmd.Flags().
+		StringVarP(
+			&options.PreReleaseChannel,
+			"prerelease",
+			"p",
+			"",
+			"Calculate the next pre-release on the given channel, e.g. 'rc' results in MAJOR.MINOR.PATCH-rc.N",
+		)
+	nextCmd.Flags().
+		BoolVar(
+			&options.Promote,
+			"promote",
+			false,
+			"Promote the latest pre-release version to its release version, e.g. 2.0.0-beta.3 results in 2.0.0",
+		)
+	nextCmd.MarkFlagsMutuallyExclusive("prerelease", "promote")
+	nextCmd.Flags().
+		BoolVar(
+			&options.Graduate,
+			"graduate",
+			false,
+			"End the initial development by turning the latest version 0.y.z into 1.0.0, regardless of the commits",
+		)
 	nextCmd.Flags().
-		StringVarP(&configPath, "config-path", "c", ".verscout-config.yaml", "The path to the verscout config file")
+		StringVar(
+			&options.Bump,
+			"bump",
+			"",
+			"Apply the given bump type instead of determining it from the commits. Either 'major', 'minor' or 'patch'",
+		)
 	nextCmd.Flags().
-		StringVarP(&firstVersion, "first-version", "f", "1.0.0", "The first version to use if no previous version tags exist")
+		StringVar(
+			&options.SetVersion,
+			"set-version",
+			"",
+			"Use the given version as the next version, if it is greater than the latest version",
+		)
+	nextCmd.MarkFlagsMutuallyExclusive("promote", "graduate", "bump", "set-version")
+	nextCmd.MarkFlagsMutuallyExclusive("prerelease", "set-version")
+	nextCmd.Flags().
+		BoolVar(
+			&options.FirstParent,
+			"first-parent",
+			false,
+			"Only follow the first parent of merge commits, so the bump is based on the merge commit messages only",
+		)
+	addSelectionStrategyFlag(nextCmd, &options.SelectionStrategy)
+	addComponentFlag(nextCmd, &options.Component)
+	nextCmd.Flags().
+		BoolVar(
+			&options.All,
+			"all",
+			false,
+			"Calculate the latest and the next version of every component defined in the config file",
+		)
+	nextCmd.Flags().
+		StringVarP(
+			(*string)(&options.Output),
+			"output",
+			"o",
+			string(OutputTable),
+			fmt.Sprintf("The output format of --all. Either '%s' or '%s'", OutputTable, OutputJSON),
+		)
+	nextCmd.MarkFlagsMutuallyExclusive("all", "component")
+	nextCmd.MarkFlagsMutuallyExclusive("all", "set-version")
 
 	return nextCmd
 }
@@ -61,48 +169,93 @@ func HandleNextCommand(
 	writer io.Writer,
 	git GitInterface,
 	repoDirectoryPath *string,
-	noNextVersionExitCode int,
-	configPath string,
-	firstVersion string,
+	options NextOptions,
 ) error {
-	config, err := semverutils.LoadBumpConfigFromFile(configPath)
+	forcedBump, err := forcedBumpType(options.Bump)
 	if err != nil {
-		if !errors.Is(err, os.ErrNotExist) {
-			return fmt.Errorf("failed to load config file: %w", err)
-		}
-
-		log.Infof("Failed to load config file: %v", err)
-		log.Info("Using default config")
+		return err
+	}
 
-		config = semverutils.DefaultBumpConfig
+	config, err := loadBumpConfig(options.ConfigPath, *repoDirectoryPath)
+	if err != nil {
+		return err
 	}
 
-	log.WithField("configFile", configPath).Info("Using config file")
+	config, err = overrideConfig(config, options.ConfigOverrideOptions)
+	if err != nil {
+		return err
+	}
 
 	repository, err := git.PlainOpen(*repoDirectoryPath)
 	if err != nil {
 		return fmt.Errorf("failed to open repository: %w", err)
 	}
 
-	tagInfo, err := gitutils.GetLatestVersionTag(repository)
+	config, err = discoverComponents(repository, options.Ref, config)
 	if err != nil {
-		log.Warnf("No version tags found: %v", err)
-		log.WithField("firstVersion", firstVersion).Info("Using provided first version")
+		return err
+	}
+
+	if options.All {
+		return handleNextAll(writer, repository, config, options)
+	}
 
-		_, err = fmt.Fprintln(writer, firstVersion)
+	if options.SetVersion != "" {
+		return handleSetVersion(writer, repository, config, options)
+	}
+
+	if options.Component != "" {
+		components, err := config.RelatedComponents(options.Component)
 		if err != nil {
-			return fmt.Errorf("failed to write version: %w", err)
+			return fmt.Errorf("failed to get component: %w", err)
 		}
 
-		return nil
+		if len(components) > 1 {
+			return handleRelatedComponents(writer, repository, components, config, options)
+		}
+	}
+
+	tagFormat, paths, err := resolveComponent(config, options.Component)
+	if err != nil {
+		return err
+	}
+
+	tagInfo, err := gitutils.GetLatestVersionTag(repository, gitutils.LatestVersionTagOptions{
+		Strategy: options.SelectionStrategy,
+		// Pre-releases are calculated based on the changes since the latest release, not the latest pre-release
+		ExcludePreReleases: options.PreReleaseChannel != "",
+		TagFormat:          tagFormat,
+		ReachableFrom:      refOrHead(options.Ref),
+	})
+	if err != nil && !errors.Is(err, gitutils.ErrNoTags) && !errors.Is(err, gitutils.ErrNoValidVersionTags) {
+		return fmt.Errorf("failed to get latest version tag: %w", err)
+	}
+
+	if err != nil {
+		log.Warnf("No version tags found: %v", err)
+		log.WithField("firstVersion", options.FirstVersion).Info("Using provided first version")
+
+		return writeNextVersion(writer, repository, options.FirstVersion, options.PreReleaseChannel, tagFormat, "")
 	}
 
-	commitMessagesSinceTag, err := gitutils.GetCommitMessagesSinceCommitHash(repository, tagInfo.Commit.Hash)
+	if options.Promote {
+		return handlePromotion(writer, tagInfo, tagFormat, options.NoNextVersionExitCode)
+	}
+
+	if options.Graduate {
+		return handleGraduation(writer, repository, tagInfo, tagFormat, options)
+	}
+
+	commitMessagesSinceTag, err := gitutils.GetCommitMessagesSinceCommitHash(
+		repository,
+		tagInfo.Commit.Hash,
+		gitutils.CommitOptions{From: refOrHead(options.Ref), FirstParent: options.FirstParent, Paths: paths},
+	)
 	if errors.Is(err, gitutils.ErrNoCommitsFound) {
 		log.Infof("No commits found since the latest version tag: %v", err)
 
-		if noNextVersionExitCode != 0 {
-			return &ExitError{Code: noNextVersionExitCode, Err: err}
+		if options.NoNextVersionExitCode != 0 {
+			return &ExitError{Code: options.NoNextVersionExitCode, Err: err}
 		}
 
 		return nil
@@ -112,10 +265,22 @@ func HandleNextCommand(
 		return fmt.Errorf("failed to get commit messages since tag: %w", err)
 	}
 
-	nextVersion, err := semverutils.CalculateNextVersion(tagInfo.Name, commitMessagesSinceTag, config)
+	// The given bump type is only applied if there are commits, like a bump determined from the commits
+	if forcedBump != semverutils.NoBump {
+		nextVersion, err := semverutils.BumpVersion(tagInfo.Version.String(), forcedBump)
+		if err != nil {
+			return fmt.Errorf("no new version calculated: %w", err)
+		}
+
+		log.WithField("bumpType", forcedBump).Info("Applied the given bump type")
+
+		return writeNextVersion(writer, repository, nextVersion, options.PreReleaseChannel, tagFormat, tagInfo.Name)
+	}
+
+	nextVersion, err := semverutils.CalculateNextVersion(tagInfo.Version.String(), commitMessagesSinceTag, config)
 	if errors.Is(err, semverutils.ErrNoBump) {
-		if noNextVersionExitCode != 0 {
-			return &ExitError{Code: noNextVersionExitCode, Err: err}
+		if options.NoNextVersionExitCode != 0 {
+			return &ExitError{Code: options.NoNextVersionExitCode, Err: err}
 		}
 
 		log.Infof("No bump detected: %v", err)
@@ -127,10 +292,193 @@ func HandleNextCommand(
 		return fmt.Errorf("no new version calculated: %w", err)
 	}
 
-	_, err = fmt.Fprintln(writer, nextVersion)
+	return writeNextVersion(writer, repository, nextVersion, options.PreReleaseChannel, tagFormat, tagInfo.Name)
+}
+
+// handlePromotion writes the release version of the latest pre-release version tag.
+// Commits since the tag are not taken into account.
+func handlePromotion(
+	writer io.Writer,
+	tagInfo *gitutils.TagInfo,
+	tagFormat *semverutils.TagFormat,
+	noNextVersionExitCode int,
+) error {
+	nextVersion, err := semverutils.PromoteVersion(tagInfo.Version.String())
+	if errors.Is(err, semverutils.ErrNotPreRelease) {
+		if noNextVersionExitCode != 0 {
+			return &ExitError{Code: noNextVersionExitCode, Err: err}
+		}
+
+		log.Infof("Nothing to promote: %v", err)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to promote version: %w", err)
+	}
+
+	log.WithField("preRelease", tagInfo.Name).Info("Promoted pre-release version")
+
+	_, err = fmt.Fprintln(writer, tagFormat.Render(nextVersion, tagInfo.Name))
+	if err != nil {
+		return fmt.Errorf("failed to write next version: %w", err)
+	}
+
+	return nil
+}
+
+// forcedBumpType returns the bump type to apply instead of determining it from the commits,
+// or NoBump if the bump type is determined from the commits.
+// Returns ErrInvalidBumpType if the bump type is not major, minor or patch.
+func forcedBumpType(bump string) (semverutils.BumpType, error) {
+	if bump == "" {
+		return semverutils.NoBump, nil
+	}
+
+	bumpType, err := semverutils.ParseBumpType(bump)
+	if err == nil && bumpType == semverutils.NoBump {
+		err = fmt.Errorf("%w: %q, expected one of patch, minor or major", semverutils.ErrInvalidBumpType, bump)
+	}
+
+	if err != nil {
+		return semverutils.NoBump, fmt.Errorf("failed to parse bump: %w", err)
+	}
+
+	return bumpType, nil
+}
+
+// handleSetVersion writes the requested version, if it is greater than the latest version tag.
+// Commits since the tag are not taken into account.
+func handleSetVersion(
+	writer io.Writer,
+	repository *git.Repository,
+	config semverutils.CompiledBumpConfig,
+	options NextOptions,
+) error {
+	tagFormat, _, err := resolveComponent(config, options.Component)
+	if err != nil {
+		return err
+	}
+
+	tagInfo, err := gitutils.GetLatestVersionTag(repository, gitutils.LatestVersionTagOptions{
+		Strategy:      options.SelectionStrategy,
+		TagFormat:     tagFormat,
+		ReachableFrom: refOrHead(options.Ref),
+	})
+	if err != nil && !errors.Is(err, gitutils.ErrNoTags) && !errors.Is(err, gitutils.ErrNoValidVersionTags) {
+		return fmt.Errorf("failed to get latest version tag: %w", err)
+	}
+
+	if err != nil {
+		log.Warnf("No version tags found: %v", err)
+
+		semVer, err := semverutils.ExtractSemVerStruct(options.SetVersion)
+		if err != nil {
+			return fmt.Errorf("failed to set version: %w", err)
+		}
+
+		return writeNextVersion(writer, repository, semVer.String(), "", tagFormat, "")
+	}
+
+	nextVersion, err := semverutils.SetVersion(tagInfo.Version.String(), options.SetVersion)
+	if errors.Is(err, semverutils.ErrVersionNotGreater) {
+		if options.NoNextVersionExitCode != 0 {
+			return &ExitError{Code: options.NoNextVersionExitCode, Err: err}
+		}
+
+		log.Warnf("Requested version not used: %v", err)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to set version: %w", err)
+	}
+
+	log.WithField("version", nextVersion).Info("Using the requested version")
+
+	return writeNextVersion(writer, repository, nextVersion, "", tagFormat, tagInfo.Name)
+}
+
+// handleGraduation writes 1.0.0 if the latest version tag is in initial development, e.g. 0.4.2.
+// Commits since the tag are not taken into account.
+func handleGraduation(
+	writer io.Writer,
+	repository *git.Repository,
+	tagInfo *gitutils.TagInfo,
+	tagFormat *semverutils.TagFormat,
+	options NextOptions,
+) error {
+	nextVersion, err := semverutils.GraduateVersion(tagInfo.Version.String())
+	if errors.Is(err, semverutils.ErrNotInitialDevelopment) {
+		if options.NoNextVersionExitCode != 0 {
+			return &ExitError{Code: options.NoNextVersionExitCode, Err: err}
+		}
+
+		log.Infof("Nothing to graduate: %v", err)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to graduate version: %w", err)
+	}
+
+	log.WithField("version", tagInfo.Name).Info("Graduated from initial development")
+
+	return writeNextVersion(writer, repository, nextVersion, options.PreReleaseChannel, tagFormat, tagInfo.Name)
+}
+
+// writeNextVersion writes the next version to the writer, rendered in the tag format.
+// The prefix and suffix of the latest tag name are kept, see semverutils.TagFormat.Render.
+// If a pre-release channel is given, the version is turned into the next pre-release on that channel
+// based on the version tags that already exist in the repository.
+func writeNextVersion(
+	writer io.Writer,
+	repository *git.Repository,
+	nextVersion string,
+	preReleaseChannel string,
+	tagFormat *semverutils.TagFormat,
+	latestTagName string,
+) error {
+	nextVersion, err := applyPreReleaseChannel(repository, nextVersion, preReleaseChannel, tagFormat)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(writer, tagFormat.Render(nextVersion, latestTagName))
 	if err != nil {
 		return fmt.Errorf("failed to write next version: %w", err)
 	}
 
 	return nil
 }
+
+// applyPreReleaseChannel turns the version into the next pre-release on the channel,
+// based on the version tags in the tag format that already exist in the repository.
+// If the channel is empty, the version is returned as is.
+func applyPreReleaseChannel(
+	repository *git.Repository,
+	version string,
+	preReleaseChannel string,
+	tagFormat *semverutils.TagFormat,
+) (string, error) {
+	if preReleaseChannel == "" {
+		return version, nil
+	}
+
+	existingVersions, err := gitutils.GetVersions(repository, tagFormat)
+	if err != nil && !errors.Is(err, gitutils.ErrNoTags) {
+		return "", fmt.Errorf("failed to get existing versions: %w", err)
+	}
+
+	preReleaseVersion, err := semverutils.CalculateNextPreReleaseVersion(version, preReleaseChannel, existingVersions)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate next pre-release version: %w", err)
+	}
+
+	log.WithField("channel", preReleaseChannel).Info("Calculated next pre-release version")
+
+	return preReleaseVersion, nil
+}
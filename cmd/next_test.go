@@ -8,6 +8,9 @@ import (
 	"time"
 
 	"github.com/erNail/verscout/internal/gitutils"
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,9 +31,7 @@ func TestHandleNextCommand_NoExistingTags_DefaultFirstVersion(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -53,9 +54,7 @@ func TestHandleNextCommand_NoExistingTags_CustomFirstVersion(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"0.1.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "0.1.0"},
 	)
 	require.NoError(t, err)
 
@@ -88,9 +87,7 @@ func TestHandleNextCommand_ValidExistingTag(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -123,13 +120,38 @@ func TestHandleNextCommand_ValidExistingTagWithVPrefix(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
-	assert.Equal(t, "1.0.1\n", output.String())
+	assert.Equal(t, "v1.0.1\n", output.String())
+}
+
+func TestHandleNextCommand_KeepsVPrefixOfLatestTag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.2.3", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "fix: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.4\n", output.String())
 }
 
 func TestHandleNextCommand_InvalidExistingTag(t *testing.T) {
@@ -158,9 +180,7 @@ func TestHandleNextCommand_InvalidExistingTag(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -193,9 +213,7 @@ func TestHandleNextCommand_MajorBump(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -228,9 +246,7 @@ func TestHandleNextCommand_MinorBump(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -263,9 +279,7 @@ func TestHandleNextCommand_NoBumpChore(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -290,9 +304,7 @@ func TestHandleNextCommand_NoBumpNoAdditionalCommits(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -325,9 +337,7 @@ func TestHandleNextCommand_AnnotatedExistingTag(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		0,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -352,9 +362,7 @@ func TestHandleNextCommand_NoNextVersionExitCode_NoNewCommits(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		2,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 2, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.Error(t, err)
 
@@ -390,9 +398,7 @@ func TestHandleNextCommand_NoNextVersionExitCode_NoBumpCommits(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		2,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 2, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.Error(t, err)
 
@@ -428,9 +434,7 @@ func TestHandleNextCommand_NoNextVersionExitCode_FixCommit(t *testing.T) {
 		&output,
 		&gitutils.MockGit{Repo: repo},
 		&repoDirectoryPath,
-		2,
-		".verscout-config.yaml",
-		"1.0.0",
+		NextOptions{NoNextVersionExitCode: 2, ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
 	)
 	require.NoError(t, err)
 
@@ -446,8 +450,9 @@ func TestNewNextCommand(t *testing.T) {
 	require.NoError(t, err)
 
 	repoDirectoryPath := "."
+	ref := "HEAD"
 
-	cmd := NewNextCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath)
+	cmd := NewNextCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath, &ref)
 	err = cmd.Execute()
 	require.NoError(t, err)
 }
@@ -483,9 +488,14 @@ bumps:
 
 	repoPath := "."
 
-	err = HandleNextCommand(&output, &gitutils.MockGit{Repo: repo}, &repoPath, 0, configPath, "1.0.0")
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: configPath, FirstVersion: "1.0.0"},
+	)
 	require.NoError(t, err)
-	assert.Equal(t, "2.0.0\n", output.String())
+	assert.Equal(t, "v2.0.0\n", output.String())
 }
 
 func TestHandleNextCommand_InvalidConfig(t *testing.T) {
@@ -511,7 +521,1456 @@ func TestHandleNextCommand_InvalidConfig(t *testing.T) {
 
 	repoPath := "."
 
-	err = HandleNextCommand(&output, &gitutils.MockGit{Repo: repo}, &repoPath, 0, configPath, "1.0.0")
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{NoNextVersionExitCode: 0, ConfigPath: configPath, FirstVersion: "1.0.0"},
+	)
 	require.Error(t, err)
 	require.NotErrorIs(t, err, os.ErrNotExist)
 }
+
+func TestHandleNextCommand_PreRelease_FirstPreRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.4.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"feat: Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", PreReleaseChannel: "rc"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.5.0-rc.1\n", output.String())
+}
+
+func TestHandleNextCommand_PreRelease_ContinuesCounterFromExistingTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.4.0", commitHash)
+	require.NoError(t, err)
+	commitHash, err = gitutils.CreateTestCommit(
+		repo,
+		"feat: Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.5.0-rc.1", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"fix: Third commit",
+		"README.md",
+		"Hello, World! Once more!",
+		time.Now().Add(2*time.Hour),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", PreReleaseChannel: "rc"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.5.0-rc.2\n", output.String())
+}
+
+func TestHandleNextCommand_ZeroPreReleaseTag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "chore: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v0.0.0-rc.1", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"feat: Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.1.0\n", output.String())
+}
+
+func TestHandleNextCommand_PreRelease_NoReleaseTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0-beta.1", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", PreReleaseChannel: "beta"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.0-beta.2\n", output.String())
+}
+
+func TestHandleNextCommand_PreRelease_InvalidChannel(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", PreReleaseChannel: "rc.1"},
+	)
+	require.ErrorIs(t, err, semverutils.ErrInvalidPreReleaseChannel)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_Promote_IgnoresCommitsSincePreRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v2.0.0-beta.3", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"feat: Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", Promote: true},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v2.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_Promote_NoCommitsSincePreRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "2.0.0-rc.1", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", Promote: true},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_Promote_LatestIsNotPreRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "2.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"feat: Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			NoNextVersionExitCode: 2,
+			ConfigPath:            ".verscout-config.yaml",
+			FirstVersion:          "1.0.0",
+			Promote:               true,
+		},
+	)
+	require.Error(t, err)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	require.ErrorIs(t, err, semverutils.ErrNotPreRelease)
+	assert.Empty(t, output.String())
+}
+
+func TestNewNextCommand_PreReleaseAndPromoteAreMutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+	ref := "HEAD"
+
+	cmd := NewNextCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath, &ref)
+	cmd.SetArgs([]string{"--prerelease", "rc", "--promote"})
+	err = cmd.Execute()
+	require.Error(t, err)
+}
+
+func TestHandleNextCommand_IgnoresTagsNotReachableFromHead(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	baseHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", baseHash)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutNewBranch(repo, "release-2", baseHash))
+	commitHash, err := gitutils.CreateTestCommit(
+		repo,
+		"feat!: Second commit",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "2.0.0", commitHash)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutBranch(repo, "master"))
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"fix: Maintenance fix",
+		"README.md",
+		"Hello, maintenance!",
+		time.Now().Add(2*time.Hour),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.1\n", output.String())
+}
+
+func TestHandleNextCommand_FirstParent(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	tagHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", tagHash)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutNewBranch(repo, "feature", tagHash))
+	featureHash, err := gitutils.CreateTestCommit(
+		repo,
+		"feat!: WIP",
+		"feature.txt",
+		"WIP",
+		baseTime.Add(time.Hour),
+	)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutBranch(repo, "master"))
+	_, err = gitutils.CreateTestMergeCommit(repo, "fix: Pull request title", featureHash, baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", FirstParent: true},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1\n", output.String())
+
+	output.Reset()
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_Ref(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	baseHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", baseHash)
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutNewBranch(repo, "release-1.x", baseHash))
+	_, err = gitutils.CreateTestCommit(repo, "fix: Backport", "README.md", "Backport", baseTime.Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, gitutils.CheckoutBranch(repo, "master"))
+	_, err = gitutils.CreateTestCommit(repo, "feat: New feature", "README.md", "Feature", baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	for ref, expected := range map[string]string{
+		"":            "1.1.0\n",
+		"master":      "1.1.0\n",
+		"release-1.x": "1.0.1\n",
+	} {
+		var output bytes.Buffer
+
+		err = HandleNextCommand(
+			&output,
+			&gitutils.MockGit{Repo: repo},
+			&repoDirectoryPath,
+			NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", Ref: ref},
+		)
+		require.NoError(t, err, ref)
+		assert.Equal(t, expected, output.String(), ref)
+	}
+}
+
+func TestHandleNextCommand_InvalidRef(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", Ref: "does-not-exist"},
+	)
+	require.Error(t, err)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_TagFormatTemplate(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`tagFormat: "release-{version}"`), 0o600))
+
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "test.txt", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "release-1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v5.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat: new feature", "test.txt", "test2", time.Now())
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	repoPath := "."
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "release-1.1.0\n", output.String())
+}
+
+func TestHandleNextCommand_TagFormatTemplate_FirstVersion(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`tagFormat: "api/v{version}"`), 0o600))
+
+	_, err = gitutils.CreateTestCommit(repo, "Initial commit", "test.txt", "test", time.Now())
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	repoPath := "."
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0", PreReleaseChannel: "rc"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "api/v1.0.0-rc.1\n", output.String())
+}
+
+func TestHandleNextCommand_TagPatternKeepsPrefixOfLatestTag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`tagPattern: "^[vV](?P<version>.+)$"`), 0o600))
+
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "test.txt", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "V1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "fix: a bug", "test.txt", "test2", time.Now())
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	repoPath := "."
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "V1.0.1\n", output.String())
+}
+
+func TestHandleNextCommand_InvalidTagFormat(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`tagFormat: "release"`), 0o600))
+
+	var output bytes.Buffer
+
+	repoPath := "."
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0"},
+	)
+	require.ErrorIs(t, err, semverutils.ErrInvalidTagFormat)
+	assert.Empty(t, output.String())
+}
+
+const componentConfig = `
+components:
+  - name: billing
+    tagPrefix: billing/v
+    paths:
+      - services/billing/**
+  - name: shipping
+    tagPrefix: shipping/v
+    paths:
+      - services/shipping/**
+`
+
+func TestHandleNextCommand_Component(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(componentConfig), 0o600))
+
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "shipping/v2.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "fix: billing", "services/billing/main.go", "fix", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat!: shipping", "services/shipping/main.go", "feat", time.Now())
+	require.NoError(t, err)
+
+	repoPath := "."
+
+	var billingOutput bytes.Buffer
+
+	err = HandleNextCommand(
+		&billingOutput,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0", Component: "billing"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "billing/v1.0.1\n", billingOutput.String())
+
+	var shippingOutput bytes.Buffer
+
+	err = HandleNextCommand(
+		&shippingOutput,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0", Component: "shipping"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "shipping/v3.0.0\n", shippingOutput.String())
+}
+
+func TestHandleNextCommand_Component_NoCommitsTouchingPaths(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(componentConfig), 0o600))
+
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat: shipping", "services/shipping/main.go", "feat", time.Now())
+	require.NoError(t, err)
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{NoNextVersionExitCode: 3, ConfigPath: configPath, FirstVersion: "1.0.0", Component: "billing"},
+	)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.Code)
+	require.ErrorIs(t, err, gitutils.ErrNoCommitsFound)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_Component_FirstVersion(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(componentConfig), 0o600))
+
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "0.1.0", Component: "shipping"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "shipping/v0.1.0\n", output.String())
+}
+
+func TestHandleNextCommand_UnknownComponent(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(componentConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0", Component: "inventory"},
+	)
+	require.ErrorIs(t, err, semverutils.ErrUnknownComponent)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_InitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v0.3.1", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat!: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("initialDevelopment: true\n"), 0o600))
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "0.1.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.4.0\n", output.String())
+}
+
+func TestHandleNextCommand_Graduate(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "0.9.2", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "0.1.0", Graduate: true},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_Graduate_PreRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "0.9.2", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:        ".verscout-config.yaml",
+			FirstVersion:      "0.1.0",
+			PreReleaseChannel: "rc",
+			Graduate:          true,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.0-rc.1\n", output.String())
+}
+
+func TestHandleNextCommand_Graduate_NotInitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.2.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			NoNextVersionExitCode: 2,
+			ConfigPath:            ".verscout-config.yaml",
+			FirstVersion:          "0.1.0",
+			Graduate:              true,
+		},
+	)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	require.ErrorIs(t, err, semverutils.ErrNotInitialDevelopment)
+	assert.Empty(t, output.String())
+}
+
+func TestNewNextCommand_PromoteAndGraduateAreMutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+	ref := "HEAD"
+
+	cmd := NewNextCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath, &ref)
+	cmd.SetArgs([]string{"--promote", "--graduate"})
+	err = cmd.Execute()
+	require.Error(t, err)
+}
+
+func TestHandleNextCommand_Bump_IgnoresCommits(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.4.2", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "chore: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", Bump: "major"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_Bump_InitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "0.4.2", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "chore: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("initialDevelopment: true\n"), 0o600))
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "1.0.0", PreReleaseChannel: "rc", Bump: "minor"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.5.0-rc.1\n", output.String())
+}
+
+func TestHandleNextCommand_Bump_NoCommitsSinceTag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			NoNextVersionExitCode: 5,
+			ConfigPath:            ".verscout-config.yaml",
+			FirstVersion:          "1.0.0",
+			Bump:                  "minor",
+		},
+	)
+	require.Error(t, err)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 5, exitErr.Code)
+	require.ErrorIs(t, err, gitutils.ErrNoCommitsFound)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_Bump_Invalid(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", Bump: "none"},
+	)
+	require.ErrorIs(t, err, semverutils.ErrInvalidBumpType)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_SetVersion(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "fix: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v1.4.2", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", SetVersion: "2.0.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v2.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_SetVersion_NoVersionTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "fix: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", SetVersion: "0.3.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.3.0\n", output.String())
+}
+
+func TestHandleNextCommand_SetVersion_NotGreater(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "fix: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "2.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			NoNextVersionExitCode: 2,
+			ConfigPath:            ".verscout-config.yaml",
+			FirstVersion:          "1.0.0",
+			SetVersion:            "1.9.0",
+		},
+	)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	require.ErrorIs(t, err, semverutils.ErrVersionNotGreater)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_SetVersion_Invalid(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "fix: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "2.0.0", commitHash)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{NoNextVersionExitCode: 2, ConfigPath: ".verscout-config.yaml", SetVersion: "three"},
+	)
+	require.ErrorIs(t, err, semverutils.ErrInvalidSemVerTag)
+
+	var exitErr *ExitError
+
+	assert.NotErrorAs(t, err, &exitErr)
+	assert.Empty(t, output.String())
+}
+
+func TestNewNextCommand_BumpAndSetVersionAreMutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+	ref := "HEAD"
+
+	cmd := NewNextCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath, &ref)
+	cmd.SetArgs([]string{"--bump", "major", "--set-version", "2.0.0"})
+	err = cmd.Execute()
+	require.Error(t, err)
+}
+
+func TestHandleNextCommand_ReleaseAsTrailer(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"fix: Second commit\n\nRelease-As: 2.0.0",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_VersionBumpTrailer(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"feat: Only a typo\n\nVersion-Bump: patch",
+		"README.md",
+		"Hello, World! Again!",
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.1\n", output.String())
+}
+
+func TestHandleNextCommand_BumpPatternFlags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feature: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				MajorPatterns: []string{"^feature:"},
+				Sources:       map[string]string{"bumps.majorPatterns": "--major-pattern"},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0.0\n", output.String())
+}
+
+func TestHandleNextCommand_InvalidBumpPatternFlag(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	err := HandleNextCommand(
+		&bytes.Buffer{},
+		&gitutils.MockGit{},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				PatchPatterns: []string{"^fix("},
+				Sources:       map[string]string{"bumps.patchPatterns": "VERSCOUT_PATCH_PATTERN"},
+			},
+		},
+	)
+	require.ErrorIs(t, err, semverutils.ErrInvalidBumpPattern)
+	assert.ErrorContains(t, err, "invalid VERSCOUT_PATCH_PATTERN: bumps.patchPatterns[0]: invalid bump pattern")
+}
+
+func TestHandleNextCommand_TypeFlag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "fix: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				Types:   []string{"fix=minor"},
+				Sources: map[string]string{"bumps.types": "--type"},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.1.0\n", output.String())
+}
+
+func TestHandleNextCommand_BreakingFlag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat!: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				Breaking: "minor",
+				Sources:  map[string]string{"bumps.breaking": "--breaking"},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.1.0\n", output.String())
+}
+
+func TestHandleNextCommand_PresetFlag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "perf: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				Preset:  "angular",
+				Sources: map[string]string{"preset": "VERSCOUT_PRESET"},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.1\n", output.String())
+}
+
+func TestHandleNextCommand_InitialDevelopmentFlag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "feat: First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "v0.3.1", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat!: Second commit", "README.md", "Hello, World! Again!", time.Now())
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				InitialDevelopment: true,
+				Sources:            map[string]string{"initialDevelopment": "--initial-development"},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.4.0\n", output.String())
+}
+
+func TestHandleNextCommand_InvalidTypeFlag(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	err := HandleNextCommand(
+		&bytes.Buffer{},
+		&gitutils.MockGit{},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				Types:   []string{"fix"},
+				Sources: map[string]string{"bumps.types": "--type"},
+			},
+		},
+	)
+	require.ErrorIs(t, err, semverutils.ErrInvalidTypeRule)
+	assert.ErrorContains(t, err, `invalid --type: invalid type rule: "fix", expected TYPE=BUMP`)
+}
+
+func TestHandleNextCommand_UnknownPresetFlag(t *testing.T) {
+	t.Parallel()
+
+	repoDirectoryPath := "."
+
+	err := HandleNextCommand(
+		&bytes.Buffer{},
+		&gitutils.MockGit{},
+		&repoDirectoryPath,
+		NextOptions{
+			ConfigPath:   ".verscout-config.yaml",
+			FirstVersion: "1.0.0",
+			ConfigOverrideOptions: ConfigOverrideOptions{
+				Preset:  "unknown",
+				Sources: map[string]string{"preset": "--preset"},
+			},
+		},
+	)
+	require.ErrorIs(t, err, semverutils.ErrUnknownPreset)
+	assert.ErrorContains(t, err, "invalid --preset:")
+}
+
+// createSideBranchTagHistory creates a long history with a feature commit since the latest mainline tag,
+// a tag on a release branch that is not reachable from HEAD, and a first tag on the root commit.
+func createSideBranchTagHistory(b *testing.B) *git.Repository {
+	b.Helper()
+
+	const (
+		historySize         = 20000
+		commitsSinceRelease = 20
+		releaseBranchFork   = 30
+	)
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(b, err)
+	mainline, err := gitutils.CreateTestHistory(repo, historySize, 3)
+	require.NoError(b, err)
+
+	_, err = gitutils.CreateTag(repo, "1.0.0", mainline[0])
+	require.NoError(b, err)
+	_, err = gitutils.CreateTag(repo, "2.1.0", mainline[len(mainline)-1-commitsSinceRelease])
+	require.NoError(b, err)
+
+	forkCommit, err := repo.CommitObject(mainline[len(mainline)-1-releaseBranchFork])
+	require.NoError(b, err)
+	backportHash, err := gitutils.CreateTestCommitObject(
+		repo,
+		"fix: Backport",
+		[]plumbing.Hash{forkCommit.Hash},
+		forkCommit.Committer.When.Add(time.Second),
+	)
+	require.NoError(b, err)
+	_, err = gitutils.CreateTag(repo, "2.0.1", backportHash)
+	require.NoError(b, err)
+
+	head, err := repo.CommitObject(mainline[len(mainline)-1])
+	require.NoError(b, err)
+	featureHash, err := gitutils.CreateTestCommitObject(
+		repo,
+		"feat: New feature",
+		[]plumbing.Hash{head.Hash},
+		head.Committer.When.Add(time.Minute),
+	)
+	require.NoError(b, err)
+	require.NoError(b, repo.Storer.SetReference(
+		plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), featureHash),
+	))
+
+	return repo
+}
+
+func BenchmarkHandleLatestCommand_SideBranchTag(b *testing.B) {
+	repo := createSideBranchTagHistory(b)
+	repoDirectoryPath := "."
+
+	b.ResetTimer()
+
+	for range b.N {
+		var output bytes.Buffer
+
+		err := HandleLatestCommand(
+			&output,
+			&gitutils.MockGit{Repo: repo},
+			&repoDirectoryPath,
+			LatestOptions{ConfigPath: ".verscout-config.yaml", Ref: "HEAD"},
+		)
+		require.NoError(b, err)
+		require.Equal(b, "2.1.0\n", output.String())
+	}
+}
+
+func BenchmarkHandleNextCommand_SideBranchTag(b *testing.B) {
+	repo := createSideBranchTagHistory(b)
+	repoDirectoryPath := "."
+
+	b.ResetTimer()
+
+	for range b.N {
+		var output bytes.Buffer
+
+		err := HandleNextCommand(
+			&output,
+			&gitutils.MockGit{Repo: repo},
+			&repoDirectoryPath,
+			NextOptions{ConfigPath: ".verscout-config.yaml", FirstVersion: "1.0.0", Ref: "HEAD"},
+		)
+		require.NoError(b, err)
+		require.Equal(b, "2.2.0\n", output.String())
+	}
+}
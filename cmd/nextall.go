@@ -0,0 +1,556 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/erNail/verscout/internal/gitutils"
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	log "github.com/sirupsen/logrus"
+)
+
+// OutputFormat defines how the versions of all components are printed.
+type OutputFormat string
+
+const (
+	// OutputTable prints the versions as a table with one row per component.
+	OutputTable OutputFormat = "table"
+	// OutputJSON prints the versions as a JSON object keyed by component name.
+	OutputJSON OutputFormat = "json"
+)
+
+var (
+	// ErrInvalidOutputFormat indicates that an unknown output format was requested.
+	ErrInvalidOutputFormat = errors.New("invalid output format")
+	// ErrNoNextVersion indicates that none of the components has a next version.
+	ErrNoNextVersion = errors.New("no next version found for any component")
+)
+
+// ComponentVersions holds the latest and the next version of a component.
+// The versions are empty if no latest version tag or no next version is found.
+type ComponentVersions struct {
+	Latest    string `json:"latest,omitempty"`
+	LatestTag string `json:"latestTag,omitempty"`
+	Next      string `json:"next,omitempty"`
+	NextTag   string `json:"nextTag,omitempty"`
+	// Bump is the bump type applied to the latest version, e.g. "minor".
+	Bump string `json:"bump,omitempty"`
+	// Reason explains why the component is bumped, if the bump is propagated from a dependency.
+	Reason string `json:"reason,omitempty"`
+}
+
+// handleNextAll calculates the latest and the next version of every component defined in the config.
+// The tags are read and the history is walked only once for all components,
+// so all versions are based on the same state of the repository.
+func handleNextAll(
+	writer io.Writer,
+	repository *git.Repository,
+	config semverutils.CompiledBumpConfig,
+	options NextOptions,
+) error {
+	if options.Output != OutputTable && options.Output != OutputJSON {
+		return fmt.Errorf("%w: %q", ErrInvalidOutputFormat, options.Output)
+	}
+
+	if len(config.Components) == 0 {
+		return fmt.Errorf("failed to calculate the versions of all components: %w", semverutils.ErrNoComponents)
+	}
+
+	versions, err := evaluateComponents(repository, config.Components, config, options)
+	if err != nil {
+		return err
+	}
+
+	err = writeComponentVersions(writer, config.Components, versions, options.Output)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(versions, func(version ComponentVersions) bool { return version.Next != "" }) &&
+		options.NoNextVersionExitCode != 0 {
+		return &ExitError{Code: options.NoNextVersionExitCode, Err: ErrNoNextVersion}
+	}
+
+	return nil
+}
+
+// handleRelatedComponents calculates the next version of the first component,
+// taking the bumps of its dependencies and the other members of its group into account.
+// See semverutils.BumpConfig.RelatedComponents.
+func handleRelatedComponents(
+	writer io.Writer,
+	repository *git.Repository,
+	components []semverutils.Component,
+	config semverutils.CompiledBumpConfig,
+	options NextOptions,
+) error {
+	versions, err := evaluateComponents(repository, components, config, options)
+	if err != nil {
+		return err
+	}
+
+	if versions[0].Next == "" {
+		if options.NoNextVersionExitCode != 0 {
+			return &ExitError{Code: options.NoNextVersionExitCode, Err: semverutils.ErrNoBump}
+		}
+
+		log.Infof("No bump detected: %v", semverutils.ErrNoBump)
+
+		return nil
+	}
+
+	_, err = fmt.Fprintln(writer, versions[0].NextTag)
+	if err != nil {
+		return fmt.Errorf("failed to write next version: %w", err)
+	}
+
+	return nil
+}
+
+// evaluateComponents calculates the latest and the next version of each of the components.
+// The tags are read and the history is walked only once for all components.
+// Components depending on a bumped component get at least a patch bump, and the members of a group
+// get the same next version, see semverutils.PropagateBumps.
+// So all dependencies and group members of the components must be part of the components.
+func evaluateComponents(
+	repository *git.Repository,
+	components []semverutils.Component,
+	config semverutils.CompiledBumpConfig,
+	options NextOptions,
+) ([]ComponentVersions, error) {
+	forcedBump, err := forcedBumpType(options.Bump)
+	if err != nil {
+		return nil, err
+	}
+
+	tagFormats := make([]*semverutils.TagFormat, 0, len(components))
+
+	for _, component := range components {
+		tagFormat, err := component.TagFormat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile tag format of component %s: %w", component.Name, err)
+		}
+
+		tagFormats = append(tagFormats, tagFormat)
+	}
+
+	latestTags, err := gitutils.GetLatestVersionTags(repository, tagFormats, gitutils.LatestVersionTagOptions{
+		Strategy:           options.SelectionStrategy,
+		ExcludePreReleases: options.PreReleaseChannel != "",
+		ReachableFrom:      refOrHead(options.Ref),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest version tags: %w", err)
+	}
+
+	commitsPerComponent, err := getCommitsPerComponent(repository, components, latestTags, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ownBumps := make(map[string]semverutils.BumpType, len(components))
+	pinnedVersions := make(map[string]*semverutils.SemVer)
+
+	for index, component := range components {
+		// Like in HandleNextCommand, the given bump type is only applied to components with commits
+		if forcedBump != semverutils.NoBump {
+			if latestTags[index] == nil || len(commitsPerComponent[index]) > 0 {
+				ownBumps[component.Name] = forcedBump
+			}
+
+			continue
+		}
+
+		commitMessages := make([]string, 0, len(commitsPerComponent[index]))
+		for _, commit := range commitsPerComponent[index] {
+			commitMessages = append(commitMessages, commit.Message)
+		}
+
+		ownBumps[component.Name] = semverutils.DetermineBumpType(commitMessages, config)
+
+		if len(commitMessages) == 0 {
+			continue
+		}
+
+		// A pinned version counts as a bump, so it is propagated to dependents and group members
+		pinnedVersion := semverutils.PinnedVersion(latestTags[index].Version, commitMessages)
+		if pinnedVersion != nil {
+			pinnedVersions[component.Name] = pinnedVersion
+			ownBumps[component.Name] = max(ownBumps[component.Name], semverutils.PatchBump)
+		}
+	}
+
+	bumps, propagations := semverutils.PropagateBumps(components, config.Groups, ownBumps)
+	baseTags := getBaseTags(components, latestTags, config.Groups)
+	versions := make([]ComponentVersions, len(components))
+
+	for index, component := range components {
+		bump := componentBump{
+			bumpType:      bumps[component.Name],
+			baseTag:       baseTags[index],
+			pinnedVersion: pinnedVersions[component.Name],
+		}
+		// A forced bump type is applied as is, even during initial development
+		if bump.baseTag != nil && forcedBump == semverutils.NoBump {
+			bump.bumpType = config.EffectiveBumpType(bump.baseTag.Version, bump.bumpType)
+		}
+
+		if propagation, propagated := propagations[component.Name]; propagated {
+			bump.reason = propagation.Reason()
+		} else if bump.pinnedVersion != nil {
+			bump.reason = "pinned by " + semverutils.ReleaseAsTrailer + " trailer"
+		}
+
+		versions[index], err = calculateComponentVersions(
+			repository,
+			latestTags[index],
+			bump,
+			tagFormats[index],
+			options,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate versions of component %s: %w", component.Name, err)
+		}
+
+		if versions[index].Reason != "" {
+			log.WithFields(log.Fields{"component": component.Name, "reason": versions[index].Reason}).
+				Info("Bump not caused by the patterns of the component's own commits")
+		}
+	}
+
+	if !options.Promote {
+		err = alignGroupVersions(components, versions, tagFormats, config.Groups)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+// getBaseTags returns the latest version tag to bump for each component.
+// This is the highest latest version tag of any member for the members of a group,
+// so all members get the same next version, and the component's own latest version tag otherwise.
+func getBaseTags(
+	components []semverutils.Component,
+	latestTags []*gitutils.TagInfo,
+	groups []semverutils.Group,
+) []*gitutils.TagInfo {
+	baseTags := slices.Clone(latestTags)
+	indexByName := make(map[string]int, len(components))
+
+	for index, component := range components {
+		indexByName[component.Name] = index
+	}
+
+	for _, group := range groups {
+		var highestTag *gitutils.TagInfo
+
+		for _, member := range group.Components {
+			index, found := indexByName[member]
+			if !found || latestTags[index] == nil {
+				continue
+			}
+
+			if highestTag == nil || latestTags[index].Version.Compare(highestTag.Version) > 0 {
+				highestTag = latestTags[index]
+			}
+		}
+
+		for _, member := range group.Components {
+			if index, found := indexByName[member]; found {
+				baseTags[index] = highestTag
+			}
+		}
+	}
+
+	return baseTags
+}
+
+// alignGroupVersions gives all members of a group that have a next version the highest next version of any member.
+// The next versions only differ in the pre-release number, if the members have different pre-release tags.
+func alignGroupVersions(
+	components []semverutils.Component,
+	versions []ComponentVersions,
+	tagFormats []*semverutils.TagFormat,
+	groups []semverutils.Group,
+) error {
+	indexByName := make(map[string]int, len(components))
+
+	for index, component := range components {
+		indexByName[component.Name] = index
+	}
+
+	for _, group := range groups {
+		var highestVersion *semverutils.SemVer
+
+		for _, member := range group.Components {
+			index, found := indexByName[member]
+			if !found || versions[index].Next == "" {
+				continue
+			}
+
+			version, err := semverutils.ExtractSemVerStruct(versions[index].Next)
+			if err != nil {
+				return fmt.Errorf("failed to align versions of group %s: %w", group.Name, err)
+			}
+
+			if highestVersion == nil || version.Compare(highestVersion) > 0 {
+				highestVersion = version
+			}
+		}
+
+		for _, member := range group.Components {
+			index, found := indexByName[member]
+			if !found || versions[index].Next == "" {
+				continue
+			}
+
+			versions[index].Next = highestVersion.String()
+			versions[index].NextTag = tagFormats[index].Render(versions[index].Next, versions[index].LatestTag)
+		}
+	}
+
+	return nil
+}
+
+// getCommitsPerComponent returns the commits since the latest version tag of each component
+// that change the files of the component. Components without a latest version tag get no commits.
+// The history is walked only once for all components.
+func getCommitsPerComponent(
+	repository *git.Repository,
+	components []semverutils.Component,
+	latestTags []*gitutils.TagInfo,
+	options NextOptions,
+) ([][]*object.Commit, error) {
+	commitsPerComponent := make([][]*object.Commit, len(components))
+	if options.Promote || options.Graduate {
+		return commitsPerComponent, nil
+	}
+
+	ranges := make([]gitutils.CommitRange, 0, len(components))
+	rangeComponents := make([]int, 0, len(components))
+
+	for index, component := range components {
+		if latestTags[index] == nil {
+			continue
+		}
+
+		ranges = append(ranges, gitutils.CommitRange{Since: latestTags[index].Commit.Hash, Paths: component.Paths})
+		rangeComponents = append(rangeComponents, index)
+	}
+
+	commitsPerRange, err := gitutils.GetCommitsForRanges(
+		repository,
+		ranges,
+		gitutils.CommitOptions{From: refOrHead(options.Ref), FirstParent: options.FirstParent},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits since the latest version tags: %w", err)
+	}
+
+	for rangeIndex, componentIndex := range rangeComponents {
+		commitsPerComponent[componentIndex] = commitsPerRange[rangeIndex]
+	}
+
+	return commitsPerComponent, nil
+}
+
+// componentBump holds the bump type of a component, the reason if it was propagated from other components
+// or pinned, the latest version tag to bump, which can belong to another member of its group, see getBaseTags,
+// and the version pinned by a Release-As trailer, if any.
+type componentBump struct {
+	bumpType      semverutils.BumpType
+	reason        string
+	baseTag       *gitutils.TagInfo
+	pinnedVersion *semverutils.SemVer
+}
+
+// calculateComponentVersions calculates the next version of a component by applying the bump to its
+// base tag. If there is no base tag, the first version is used.
+func calculateComponentVersions(
+	repository *git.Repository,
+	latestTag *gitutils.TagInfo,
+	bump componentBump,
+	tagFormat *semverutils.TagFormat,
+	options NextOptions,
+) (ComponentVersions, error) {
+	var versions ComponentVersions
+
+	latestTagName := ""
+
+	if latestTag != nil {
+		versions.Latest = latestTag.Version.String()
+		versions.LatestTag = latestTag.Name
+		latestTagName = latestTag.Name
+	}
+
+	nextVersion, err := calculateNextComponentVersion(latestTag, bump, options)
+	if err != nil {
+		return ComponentVersions{}, err
+	}
+
+	if nextVersion == "" {
+		return versions, nil
+	}
+
+	if bump.baseTag != nil && !options.Promote && !options.Graduate {
+		versions.Bump = bump.bumpType.String()
+		versions.Reason = bump.reason
+	}
+
+	nextVersion, err = applyPreReleaseChannel(repository, nextVersion, options.PreReleaseChannel, tagFormat)
+	if err != nil {
+		return ComponentVersions{}, err
+	}
+
+	versions.Next = nextVersion
+	versions.NextTag = tagFormat.Render(nextVersion, latestTagName)
+
+	return versions, nil
+}
+
+// calculateNextComponentVersion returns the next version of a component, or an empty string if there is no bump
+// or nothing to promote.
+func calculateNextComponentVersion(
+	latestTag *gitutils.TagInfo,
+	bump componentBump,
+	options NextOptions,
+) (string, error) {
+	if options.Promote {
+		if latestTag == nil {
+			return options.FirstVersion, nil
+		}
+
+		nextVersion, err := semverutils.PromoteVersion(latestTag.Version.String())
+		if errors.Is(err, semverutils.ErrNotPreRelease) {
+			log.WithField("tag", latestTag.Name).Infof("Nothing to promote: %v", err)
+
+			return "", nil
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("failed to promote version: %w", err)
+		}
+
+		return nextVersion, nil
+	}
+
+	if bump.baseTag == nil {
+		return options.FirstVersion, nil
+	}
+
+	if options.Graduate {
+		nextVersion, err := semverutils.GraduateVersion(bump.baseTag.Version.String())
+		if errors.Is(err, semverutils.ErrNotInitialDevelopment) {
+			log.WithField("tag", bump.baseTag.Name).Infof("Nothing to graduate: %v", err)
+
+			return "", nil
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("failed to graduate version: %w", err)
+		}
+
+		return nextVersion, nil
+	}
+
+	// The base tag of a group member can be higher than the latest version tag the version was pinned against
+	if bump.pinnedVersion != nil && bump.pinnedVersion.Compare(bump.baseTag.Version) > 0 {
+		log.WithField("version", bump.pinnedVersion.String()).
+			Infof("Using version pinned by %s trailer", semverutils.ReleaseAsTrailer)
+
+		return bump.pinnedVersion.String(), nil
+	}
+
+	nextVersion, err := semverutils.BumpVersion(bump.baseTag.Version.String(), bump.bumpType)
+	if errors.Is(err, semverutils.ErrNoBump) {
+		log.WithField("tag", bump.baseTag.Name).Infof("No bump detected: %v", err)
+
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("no new version calculated: %w", err)
+	}
+
+	return nextVersion, nil
+}
+
+// writeComponentVersions writes the versions of all components in the output format.
+func writeComponentVersions(
+	writer io.Writer,
+	components []semverutils.Component,
+	versions []ComponentVersions,
+	output OutputFormat,
+) error {
+	if output == OutputJSON {
+		versionsByName := make(map[string]ComponentVersions, len(components))
+		for index, component := range components {
+			versionsByName[component.Name] = versions[index]
+		}
+
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(versionsByName)
+		if err != nil {
+			return fmt.Errorf("failed to write versions: %w", err)
+		}
+
+		return nil
+	}
+
+	// The reasons are only shown if any bump was propagated from a dependency
+	withReasons := slices.ContainsFunc(versions, func(version ComponentVersions) bool { return version.Reason != "" })
+	tableWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	header := []string{"COMPONENT", "LATEST", "NEXT"}
+	if withReasons {
+		header = append(header, "REASON")
+	}
+
+	_, err := fmt.Fprintln(tableWriter, strings.Join(header, "\t"))
+	if err != nil {
+		return fmt.Errorf("failed to write versions: %w", err)
+	}
+
+	for index, component := range components {
+		row := []string{
+			component.Name,
+			valueOrDash(versions[index].LatestTag),
+			valueOrDash(versions[index].NextTag),
+		}
+		if withReasons {
+			row = append(row, valueOrDash(versions[index].Reason))
+		}
+
+		_, err = fmt.Fprintln(tableWriter, strings.Join(row, "\t"))
+		if err != nil {
+			return fmt.Errorf("failed to write versions: %w", err)
+		}
+	}
+
+	err = tableWriter.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write versions: %w", err)
+	}
+
+	return nil
+}
+
+// valueOrDash returns the value, or "-" if it is empty.
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
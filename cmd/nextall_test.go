@@ -0,0 +1,809 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/erNail/verscout/internal/gitutils"
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const allComponentsConfig = `
+components:
+  - name: billing
+    paths:
+      - services/billing/**
+  - name: shipping
+    paths:
+      - services/shipping/**
+  - name: inventory
+    paths:
+      - services/inventory/**
+`
+
+// createComponentsTestRepo creates a repository with version tags for billing and shipping, but not inventory,
+// and a fix for billing since then.
+func createComponentsTestRepo(t *testing.T) *git.Repository {
+	t.Helper()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "shipping/v2.1.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "fix: billing", "services/billing/main.go", "fix", time.Now())
+	require.NoError(t, err)
+
+	return repo
+}
+
+func TestHandleNextCommand_All_Table(t *testing.T) {
+	t.Parallel()
+
+	repo := createComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(allComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath:   configPath,
+			FirstVersion: "0.1.0",
+			All:          true,
+			Output:       OutputTable,
+		},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT  LATEST           NEXT\n" +
+		"billing    billing/v1.0.0   billing/v1.0.1\n" +
+		"shipping   shipping/v2.1.0  -\n" +
+		"inventory  -                inventory/v0.1.0\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleNextCommand_All_JSON(t *testing.T) {
+	t.Parallel()
+
+	repo := createComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(allComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath:        configPath,
+			FirstVersion:      "1.0.0",
+			PreReleaseChannel: "rc",
+			All:               true,
+			Output:            OutputJSON,
+		},
+	)
+	require.NoError(t, err)
+
+	var versions map[string]ComponentVersions
+
+	require.NoError(t, json.Unmarshal(output.Bytes(), &versions))
+	assert.Equal(t, map[string]ComponentVersions{
+		"billing": {
+			Latest:    "1.0.0",
+			LatestTag: "billing/v1.0.0",
+			Next:      "1.0.1-rc.1",
+			NextTag:   "billing/v1.0.1-rc.1",
+			Bump:      "patch",
+		},
+		"shipping":  {Latest: "2.1.0", LatestTag: "shipping/v2.1.0"},
+		"inventory": {Next: "1.0.0-rc.1", NextTag: "inventory/v1.0.0-rc.1"},
+	}, versions)
+}
+
+func TestHandleNextCommand_All_NoNextVersionExitCode(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat: shipping", "services/shipping/main.go", "feat", time.Now())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(componentConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			NoNextVersionExitCode: 4,
+			ConfigPath:            configPath,
+			All:                   true,
+			Output:                OutputTable,
+		},
+	)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 4, exitErr.Code)
+	require.ErrorIs(t, err, ErrNoNextVersion)
+	assert.Contains(t, output.String(), "billing")
+}
+
+func TestHandleNextCommand_All_NoComponents(t *testing.T) {
+	t.Parallel()
+
+	repo := createComponentsTestRepo(t)
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: ".verscout-config.yaml", All: true, Output: OutputTable},
+	)
+	require.ErrorIs(t, err, semverutils.ErrNoComponents)
+	assert.Empty(t, output.String())
+}
+
+func TestHandleNextCommand_All_InvalidOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	repo := createComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(allComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, All: true, Output: "yaml"},
+	)
+	require.ErrorIs(t, err, ErrInvalidOutputFormat)
+	assert.Empty(t, output.String())
+}
+
+func TestNewNextCommand_AllAndComponentAreMutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+
+	repoDirectoryPath := "."
+	ref := "HEAD"
+
+	cmd := NewNextCmd(&gitutils.MockGit{Repo: repo}, &repoDirectoryPath, &ref)
+	cmd.SetArgs([]string{"--all", "--component", "billing"})
+	err = cmd.Execute()
+	require.Error(t, err)
+}
+
+const dependentComponentsConfig = `
+components:
+  - name: billing
+    paths:
+      - services/billing/**
+  - name: shipping
+    paths:
+      - services/shipping/**
+    dependsOn:
+      - billing
+  - name: storefront
+    paths:
+      - services/storefront/**
+    dependsOn:
+      - shipping
+`
+
+// createDependentComponentsTestRepo creates a repository with version tags for billing, shipping and storefront,
+// and a feature for billing since then.
+func createDependentComponentsTestRepo(t *testing.T) *git.Repository {
+	t.Helper()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+
+	for _, tagName := range []string{"billing/v1.0.0", "shipping/v2.1.0", "storefront/v0.3.0"} {
+		_, err = gitutils.CreateTag(repo, tagName, commitHash)
+		require.NoError(t, err)
+	}
+
+	_, err = gitutils.CreateTestCommit(repo, "feat: billing", "services/billing/main.go", "feat", time.Now())
+	require.NoError(t, err)
+
+	return repo
+}
+
+func TestHandleNextCommand_All_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	repo := createDependentComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(dependentComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath: configPath,
+			All:        true,
+			Output:     OutputTable,
+		},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT   LATEST             NEXT               REASON\n" +
+		"billing     billing/v1.0.0     billing/v1.1.0     -\n" +
+		"shipping    shipping/v2.1.0    shipping/v2.1.1    depends on billing, which is bumped\n" +
+		"storefront  storefront/v0.3.0  storefront/v0.3.1  depends on shipping -> billing, which is bumped\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleNextCommand_All_DependsOnJSON(t *testing.T) {
+	t.Parallel()
+
+	repo := createDependentComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(dependentComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath: configPath,
+			All:        true,
+			Output:     OutputJSON,
+		},
+	)
+	require.NoError(t, err)
+
+	var versions map[string]ComponentVersions
+
+	require.NoError(t, json.Unmarshal(output.Bytes(), &versions))
+	assert.Equal(t, ComponentVersions{
+		Latest:    "2.1.0",
+		LatestTag: "shipping/v2.1.0",
+		Next:      "2.1.1",
+		NextTag:   "shipping/v2.1.1",
+		Bump:      "patch",
+		Reason:    "depends on billing, which is bumped",
+	}, versions["shipping"])
+}
+
+func TestHandleNextCommand_ComponentWithBumpedDependency(t *testing.T) {
+	t.Parallel()
+
+	repo := createDependentComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(dependentComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, Component: "storefront"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "storefront/v0.3.1\n", output.String())
+}
+
+func TestHandleNextCommand_ComponentWithUnchangedDependency(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "shipping/v2.1.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat: storefront", "services/storefront/main.go", "feat", time.Now())
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(dependentComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			NoNextVersionExitCode: 4,
+			ConfigPath:            configPath,
+			Component:             "shipping",
+		},
+	)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 4, exitErr.Code)
+	require.ErrorIs(t, err, semverutils.ErrNoBump)
+	assert.Empty(t, output.String())
+}
+
+const groupComponentsConfig = `
+components:
+  - name: cli
+    paths:
+      - cli/**
+  - name: sdk
+    paths:
+      - sdk/**
+  - name: billing
+    paths:
+      - services/billing/**
+groups:
+  - name: tools
+    components:
+      - cli
+      - sdk
+`
+
+// createGroupComponentsTestRepo creates a repository with version tags for cli and sdk,
+// where sdk is behind cli, and a feature for sdk since then.
+func createGroupComponentsTestRepo(t *testing.T) *git.Repository {
+	t.Helper()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "cli/v1.2.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "sdk/v1.1.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat: sdk", "sdk/main.go", "feat", time.Now())
+	require.NoError(t, err)
+
+	return repo
+}
+
+func TestHandleNextCommand_All_Group(t *testing.T) {
+	t.Parallel()
+
+	repo := createGroupComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(groupComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath:   configPath,
+			FirstVersion: "0.1.0",
+			All:          true,
+			Output:       OutputTable,
+		},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT  LATEST      NEXT            REASON\n" +
+		"cli        cli/v1.2.0  cli/v1.3.0      versioned in lockstep with group tools\n" +
+		"sdk        sdk/v1.1.0  sdk/v1.3.0      -\n" +
+		"billing    -           billing/v0.1.0  -\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleNextCommand_All_GroupPreRelease(t *testing.T) {
+	t.Parallel()
+
+	repo := createGroupComponentsTestRepo(t)
+	headRef, err := repo.Head()
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "cli/v1.3.0-rc.1", headRef.Hash())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(groupComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath:        configPath,
+			PreReleaseChannel: "rc",
+			All:               true,
+			Output:            OutputJSON,
+		},
+	)
+	require.NoError(t, err)
+
+	var versions map[string]ComponentVersions
+
+	require.NoError(t, json.Unmarshal(output.Bytes(), &versions))
+	assert.Equal(t, "cli/v1.3.0-rc.2", versions["cli"].NextTag)
+	assert.Equal(t, "sdk/v1.3.0-rc.2", versions["sdk"].NextTag)
+}
+
+func TestHandleNextCommand_ComponentInGroup(t *testing.T) {
+	t.Parallel()
+
+	repo := createGroupComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(groupComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, Component: "cli"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "cli/v1.3.0\n", output.String())
+}
+
+func TestHandleNextCommand_All_DiscoveredComponents(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"Initial commit",
+		"package.json",
+		`{"workspaces": ["packages/*"]}`,
+		time.Now(),
+	)
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(
+		repo,
+		"feat: ui",
+		"packages/ui/package.json",
+		`{"name": "@acme/ui"}`,
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "@acme/ui@1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "fix: ui", "packages/ui/index.js", "fix", time.Now())
+	require.NoError(t, err)
+
+	config := `
+discover:
+  - npm
+components:
+  - name: app
+    paths:
+      - app/**
+    dependsOn:
+      - "@acme/ui"
+`
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "0.1.0", All: true, Output: OutputTable},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT  LATEST          NEXT\n" +
+		"app        -               app/v0.1.0\n" +
+		"@acme/ui   @acme/ui@1.0.0  @acme/ui@1.0.1\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleNextCommand_All_DiscoveredNestedGoModule(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(
+		repo,
+		"feat: auth",
+		"libs/auth/go.mod",
+		"module example.com/acme/libs/auth",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(
+		repo,
+		"feat: sub",
+		"libs/auth/sub/go.mod",
+		"module example.com/acme/libs/auth/sub",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "libs/auth/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "libs/auth/sub/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat: sub feature", "libs/auth/sub/sub.go", "package sub", time.Now())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("discover:\n  - go\n"), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{ConfigPath: configPath, FirstVersion: "0.1.0", All: true, Output: OutputTable},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT      LATEST                NEXT\n" +
+		"libs/auth      libs/auth/v1.0.0      -\n" +
+		"libs/auth/sub  libs/auth/sub/v1.0.0  libs/auth/sub/v1.1.0\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleNextCommand_All_Graduate(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v0.4.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "shipping/v2.1.0", commitHash)
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(allComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath:   configPath,
+			FirstVersion: "0.1.0",
+			Graduate:     true,
+			All:          true,
+			Output:       OutputTable,
+		},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT  LATEST           NEXT\n" +
+		"billing    billing/v0.4.0   billing/v1.0.0\n" +
+		"shipping   shipping/v2.1.0  -\n" +
+		"inventory  -                inventory/v0.1.0\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleNextCommand_All_InitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v0.4.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "feat!: billing", "services/billing/main.go", "feat", time.Now())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("initialDevelopment: true\n"+allComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath: configPath,
+			All:        true,
+			Output:     OutputJSON,
+		},
+	)
+	require.NoError(t, err)
+
+	var versions map[string]ComponentVersions
+
+	require.NoError(t, json.Unmarshal(output.Bytes(), &versions))
+	assert.Equal(t, "billing/v0.5.0", versions["billing"].NextTag)
+	assert.Equal(t, "minor", versions["billing"].Bump)
+}
+
+func TestHandleNextCommand_All_Bump(t *testing.T) {
+	t.Parallel()
+
+	repo := createComponentsTestRepo(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(allComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err := HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath:   configPath,
+			FirstVersion: "0.1.0",
+			Bump:         "minor",
+			All:          true,
+			Output:       OutputTable,
+		},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT  LATEST           NEXT\n" +
+		"billing    billing/v1.0.0   billing/v1.1.0\n" +
+		"shipping   shipping/v2.1.0  -\n" +
+		"inventory  -                inventory/v0.1.0\n"
+	assert.Equal(t, expected, output.String())
+}
+
+func TestHandleNextCommand_All_Bump_NoNextVersionExitCode(t *testing.T) {
+	t.Parallel()
+
+	repo, err := gitutils.CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := gitutils.CreateTestCommit(repo, "Initial commit", "README.md", "test", time.Now())
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "billing/v1.0.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTag(repo, "shipping/v2.1.0", commitHash)
+	require.NoError(t, err)
+	_, err = gitutils.CreateTestCommit(repo, "docs: readme", "README.md", "docs", time.Now())
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(componentConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			NoNextVersionExitCode: 5,
+			ConfigPath:            configPath,
+			Bump:                  "minor",
+			All:                   true,
+			Output:                OutputTable,
+		},
+	)
+
+	var exitErr *ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 5, exitErr.Code)
+	require.ErrorIs(t, err, ErrNoNextVersion)
+}
+
+func TestHandleNextCommand_All_ReleaseAsTrailer(t *testing.T) {
+	t.Parallel()
+
+	repo := createDependentComponentsTestRepo(t)
+	_, err := gitutils.CreateTestCommit(
+		repo,
+		"chore: shipping\n\nRelease-As: 3.0.0",
+		"services/shipping/main.go",
+		"chore",
+		time.Now(),
+	)
+	require.NoError(t, err)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(dependentComponentsConfig), 0o600))
+
+	repoPath := "."
+
+	var output bytes.Buffer
+
+	err = HandleNextCommand(
+		&output,
+		&gitutils.MockGit{Repo: repo},
+		&repoPath,
+		NextOptions{
+			ConfigPath: configPath,
+			All:        true,
+			Output:     OutputTable,
+		},
+	)
+	require.NoError(t, err)
+
+	expected := "COMPONENT   LATEST             NEXT               REASON\n" +
+		"billing     billing/v1.0.0     billing/v1.1.0     -\n" +
+		"shipping    shipping/v2.1.0    shipping/v3.0.0    pinned by Release-As trailer\n" +
+		"storefront  storefront/v0.3.0  storefront/v0.3.1  depends on shipping, which is bumped\n"
+	assert.Equal(t, expected, output.String())
+}
@@ -51,6 +51,8 @@ var version = "dev"
 func NewRootCmd() *cobra.Command {
 	var repoDirectoryPath string
 
+	var ref string
+
 	rootCmd := &cobra.Command{
 		Use:           "verscout",
 		Short:         "Find the latest version tag and calculate the next version",
@@ -58,11 +60,23 @@ func NewRootCmd() *cobra.Command {
 		Version:       version,
 		SilenceErrors: true,
 		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return applyEnvironmentVariables(cmd.Flags(), os.LookupEnv)
+		},
 	}
 
 	rootCmd.PersistentFlags().StringVarP(&repoDirectoryPath, "dir", "d", ".", "directory path to the git repository")
-	rootCmd.AddCommand(NewLatestCmd(&Git{}, &repoDirectoryPath))
-	rootCmd.AddCommand(NewNextCmd(&Git{}, &repoDirectoryPath))
+	rootCmd.PersistentFlags().
+		StringVarP(
+			&ref,
+			"ref",
+			"r",
+			"HEAD",
+			"branch, tag or commit to evaluate the versions at, as if it were checked out",
+		)
+	rootCmd.AddCommand(NewLatestCmd(&Git{}, &repoDirectoryPath, &ref))
+	rootCmd.AddCommand(NewNextCmd(&Git{}, &repoDirectoryPath, &ref))
+	rootCmd.AddCommand(NewConfigCmd(&repoDirectoryPath))
 
 	return rootCmd
 }
@@ -49,3 +49,25 @@ func TestRootCmdCallsNextSubcommand(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestRootCmdAcceptsRefFlag(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"--ref", "main", "latest", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
+
+func TestRootCmdCallsConfigSubcommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"config", "validate", "-h"})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+}
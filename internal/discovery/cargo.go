@@ -0,0 +1,82 @@
+package discovery
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/erNail/verscout/internal/semverutils"
+)
+
+// cargoTOML holds the fields of a Cargo.toml used for discovery.
+type cargoTOML struct {
+	Package struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Workspace struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+// discoverCargoWorkspaces discovers the crates of the workspace members in the root Cargo.toml.
+// The tag prefixes follow the convention of cargo-release, e.g. "my-crate-v" for "my-crate-v1.2.3".
+func discoverCargoWorkspaces(files Files, paths []string) ([]semverutils.Component, error) {
+	if !slices.Contains(paths, "Cargo.toml") {
+		return nil, nil
+	}
+
+	rootManifest, err := readCargoTOML(files, "Cargo.toml")
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := filterWorkspaceDirs(
+		manifestDirs(paths, "Cargo.toml"),
+		rootManifest.Workspace.Members,
+		rootManifest.Workspace.Exclude,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]semverutils.Component, 0, len(dirs))
+
+	for _, dir := range dirs {
+		manifest, err := readCargoTOML(files, path.Join(dir, "Cargo.toml"))
+		if err != nil {
+			return nil, err
+		}
+
+		name := manifest.Package.Name
+		if name == "" {
+			name = path.Base(dir)
+		}
+
+		components = append(components, semverutils.Component{
+			Name:      name,
+			TagPrefix: name + "-v",
+			Paths:     []string{dir},
+		})
+	}
+
+	return componentsByDir(components), nil
+}
+
+// readCargoTOML reads and parses the Cargo.toml at the path.
+func readCargoTOML(files Files, filePath string) (cargoTOML, error) {
+	content, err := files.ReadFile(filePath)
+	if err != nil {
+		return cargoTOML{}, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	var manifest cargoTOML
+
+	err = toml.Unmarshal(content, &manifest)
+	if err != nil {
+		return cargoTOML{}, fmt.Errorf("%w: %s: %w", ErrInvalidManifest, filePath, err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,60 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverCargoWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	rootManifest := `
+[workspace]
+members = ["crates/*", "tools/xtask"]
+exclude = ["crates/scratch"]
+`
+	files := testFiles{
+		"Cargo.toml":                rootManifest,
+		"crates/core/Cargo.toml":    "[package]\nname = \"acme-core\"\nversion = \"0.1.0\"\n",
+		"crates/scratch/Cargo.toml": "[package]\nname = \"scratch\"\n",
+		"tools/xtask/Cargo.toml":    "[package]\nversion = { workspace = true }\n",
+		"examples/demo/Cargo.toml":  "[package]\nname = \"demo\"\n",
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverCargoWorkspaces(files, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{Name: "acme-core", TagPrefix: "acme-core-v", Paths: []string{"crates/core"}},
+		{Name: "xtask", TagPrefix: "xtask-v", Paths: []string{"tools/xtask"}},
+	}, components)
+}
+
+func TestDiscoverCargoWorkspaces_InvalidCargoTOML(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{"Cargo.toml": "[workspace\n"}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	_, err = discoverCargoWorkspaces(files, paths)
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
+
+func TestDiscoverCargoWorkspaces_InvalidMemberGlob(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"Cargo.toml":             "[workspace]\nmembers = [\"crates/[\"]\n",
+		"crates/core/Cargo.toml": "[package]\nname = \"acme-core\"\n",
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	_, err = discoverCargoWorkspaces(files, paths)
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
@@ -0,0 +1,148 @@
+// Package discovery finds the components of a monorepo in its workspace manifests,
+// e.g. go.work, package.json or Cargo.toml.
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	log "github.com/sirupsen/logrus"
+)
+
+// Ecosystem defines which workspace manifests are used to discover components.
+type Ecosystem string
+
+const (
+	// Go discovers the Go modules used in go.work, or all nested go.mod files if there is no go.work.
+	Go Ecosystem = "go"
+	// NPM discovers the packages of the workspaces in the root package.json.
+	NPM Ecosystem = "npm"
+	// Cargo discovers the crates of the workspace members in the root Cargo.toml.
+	Cargo Ecosystem = "cargo"
+)
+
+var (
+	// ErrUnknownEcosystem indicates that components cannot be discovered for an ecosystem.
+	ErrUnknownEcosystem = errors.New("unknown ecosystem")
+	// ErrInvalidManifest indicates that a workspace manifest cannot be parsed.
+	ErrInvalidManifest = errors.New("invalid manifest")
+)
+
+// Files gives read access to the files of a repository.
+type Files interface {
+	// Paths returns the slash separated paths of all files, relative to the repository root.
+	Paths() ([]string, error)
+	// ReadFile returns the content of the file at the path relative to the repository root.
+	ReadFile(filePath string) ([]byte, error)
+}
+
+// DiscoverComponents discovers the components in the workspace manifests of the ecosystems.
+// The components are returned in the order of the ecosystems, and ordered by their directory per ecosystem.
+// Returns ErrUnknownEcosystem or ErrInvalidManifest if the components cannot be discovered.
+func DiscoverComponents(files Files, ecosystems []Ecosystem) ([]semverutils.Component, error) {
+	paths, err := files.Paths()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	var components []semverutils.Component
+
+	for _, ecosystem := range ecosystems {
+		var discovered []semverutils.Component
+
+		switch ecosystem {
+		case Go:
+			discovered, err = discoverGoModules(files, paths)
+		case NPM:
+			discovered, err = discoverNPMWorkspaces(files, paths)
+		case Cargo:
+			discovered, err = discoverCargoWorkspaces(files, paths)
+		default:
+			return nil, fmt.Errorf(
+				"%w: %q, expected one of %s, %s or %s",
+				ErrUnknownEcosystem,
+				ecosystem,
+				Go,
+				NPM,
+				Cargo,
+			)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover %s components: %w", ecosystem, err)
+		}
+
+		log.WithFields(log.Fields{"ecosystem": ecosystem, "components": len(discovered)}).
+			Info("Discovered components")
+
+		components = append(components, discovered...)
+	}
+
+	return components, nil
+}
+
+// manifestDirs returns the directories containing a file with the manifest name, except the repository root.
+func manifestDirs(paths []string, manifestName string) []string {
+	var dirs []string
+
+	for _, filePath := range paths {
+		if path.Base(filePath) == manifestName && filePath != manifestName {
+			dirs = append(dirs, path.Dir(filePath))
+		}
+	}
+
+	return dirs
+}
+
+// filterWorkspaceDirs returns the directories matching any of the globs and none of the excluded globs.
+// The globs are matched using path.Match, and may start with "./".
+func filterWorkspaceDirs(dirs []string, globs []string, excludedGlobs []string) ([]string, error) {
+	var matchingDirs []string
+
+	for _, dir := range dirs {
+		included, err := matchesAnyGlob(dir, globs)
+		if err != nil {
+			return nil, err
+		}
+
+		excluded, err := matchesAnyGlob(dir, excludedGlobs)
+		if err != nil {
+			return nil, err
+		}
+
+		if included && !excluded {
+			matchingDirs = append(matchingDirs, dir)
+		}
+	}
+
+	return matchingDirs, nil
+}
+
+// matchesAnyGlob reports whether the directory matches any of the globs.
+func matchesAnyGlob(dir string, globs []string) (bool, error) {
+	for _, glob := range globs {
+		matched, err := path.Match(path.Clean(strings.TrimPrefix(glob, "./")), dir)
+		if err != nil {
+			return false, fmt.Errorf("%w: glob %q: %w", ErrInvalidManifest, glob, err)
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// componentsByDir sorts the components by their directory, which is their only path.
+func componentsByDir(components []semverutils.Component) []semverutils.Component {
+	slices.SortFunc(components, func(component semverutils.Component, other semverutils.Component) int {
+		return strings.Compare(component.Paths[0], other.Paths[0])
+	})
+
+	return components
+}
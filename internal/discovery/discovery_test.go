@@ -0,0 +1,62 @@
+package discovery
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFiles maps the paths of the files in a test repository to their content.
+type testFiles map[string]string
+
+func (files testFiles) Paths() ([]string, error) {
+	return slices.Sorted(maps.Keys(files)), nil
+}
+
+func (files testFiles) ReadFile(filePath string) ([]byte, error) {
+	content, found := files[filePath]
+	if !found {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, os.ErrNotExist)
+	}
+
+	return []byte(content), nil
+}
+
+func TestDiscoverComponents_OrderOfEcosystems(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"Cargo.toml":            "[workspace]\nmembers = [\"crates/*\"]\n",
+		"crates/cli/Cargo.toml": "[package]\nname = \"acme-cli\"\n",
+		"libs/auth/go.mod":      "module example.com/acme/libs/auth\n",
+	}
+
+	components, err := DiscoverComponents(files, []Ecosystem{Cargo, Go})
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{Name: "acme-cli", TagPrefix: "acme-cli-v", Paths: []string{"crates/cli"}},
+		{Name: "libs/auth", TagPrefix: "libs/auth/v", Paths: []string{"libs/auth"}},
+	}, components)
+}
+
+func TestDiscoverComponents_UnknownEcosystem(t *testing.T) {
+	t.Parallel()
+
+	components, err := DiscoverComponents(testFiles{}, []Ecosystem{"maven"})
+	require.ErrorIs(t, err, ErrUnknownEcosystem)
+	assert.Nil(t, components)
+}
+
+func TestDiscoverComponents_NoManifests(t *testing.T) {
+	t.Parallel()
+
+	components, err := DiscoverComponents(testFiles{"README.md": "test"}, []Ecosystem{Go, NPM, Cargo})
+	require.NoError(t, err)
+	assert.Empty(t, components)
+}
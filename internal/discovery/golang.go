@@ -0,0 +1,130 @@
+package discovery
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// discoverGoModules discovers the Go modules in subdirectories of the repository.
+// The modules used in go.work are discovered if it exists, otherwise all nested go.mod files.
+// The module in the repository root is not a component, since its version tags have no prefix.
+// The tag prefixes follow the Go convention for modules in subdirectories, e.g. "libs/auth/v" for "libs/auth",
+// or for "libs/auth/v2" with the module path ".../libs/auth/v2".
+// Like the go tool, the paths of a module exclude the directories of the modules nested in it.
+func discoverGoModules(files Files, paths []string) ([]semverutils.Component, error) {
+	dirs, err := goModuleDirs(files, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]semverutils.Component, 0, len(dirs))
+
+	for _, dir := range dirs {
+		goModPath := path.Join(dir, "go.mod")
+
+		content, err := files.ReadFile(goModPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", goModPath, err)
+		}
+
+		modulePath := modfile.ModulePath(content)
+		if modulePath == "" {
+			return nil, fmt.Errorf("%w: %s has no module directive", ErrInvalidManifest, goModPath)
+		}
+
+		components = append(components, semverutils.Component{
+			Name:      dir,
+			TagPrefix: goTagPrefix(dir, modulePath),
+			Paths:     goModulePaths(dir, dirs),
+		})
+	}
+
+	return componentsByDir(components), nil
+}
+
+// goModulePaths returns the paths of the module in the directory, excluding the directories of nested modules,
+// e.g. "libs/auth" and "!libs/auth/sub" if "libs/auth/sub" is a module as well.
+func goModulePaths(dir string, dirs []string) []string {
+	paths := []string{dir}
+
+	for _, other := range dirs {
+		if strings.HasPrefix(other, dir+"/") {
+			paths = append(paths, "!"+other)
+		}
+	}
+
+	return paths
+}
+
+// goModuleDirs returns the directories of the Go modules, except the repository root.
+func goModuleDirs(files Files, paths []string) ([]string, error) {
+	if !slices.Contains(paths, "go.work") {
+		return slices.DeleteFunc(manifestDirs(paths, "go.mod"), isIgnoredByGo), nil
+	}
+
+	content, err := files.ReadFile("go.work")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+
+	workFile, err := modfile.ParseWork("go.work", content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	dirs := make([]string, 0, len(workFile.Use))
+
+	for _, use := range workFile.Use {
+		dir := path.Clean(use.Path)
+		if dir == "." {
+			continue
+		}
+
+		if path.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+			log.WithField("module", use.Path).Warn("Ignoring module outside of the repository")
+
+			continue
+		}
+
+		dirs = append(dirs, dir)
+	}
+
+	return dirs, nil
+}
+
+// isIgnoredByGo reports whether the go tool ignores the directory, because one of its elements is "testdata"
+// or starts with "." or "_".
+func isIgnoredByGo(dir string) bool {
+	for element := range strings.SplitSeq(dir, "/") {
+		if element == "testdata" || strings.HasPrefix(element, ".") || strings.HasPrefix(element, "_") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// goTagPrefix returns the prefix of the version tags of the module in the directory.
+// If the module lives in a major version subdirectory, e.g. "libs/auth/v2" for ".../libs/auth/v2",
+// the subdirectory is not part of the prefix, since the major version is already part of the version.
+func goTagPrefix(dir string, modulePath string) string {
+	prefixDir := dir
+
+	_, majorSuffix, ok := module.SplitPathVersion(modulePath)
+	if ok && majorSuffix != "" && "/"+path.Base(dir) == majorSuffix {
+		prefixDir = path.Dir(dir)
+	}
+
+	if prefixDir == "." {
+		return "v"
+	}
+
+	return prefixDir + "/v"
+}
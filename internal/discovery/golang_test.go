@@ -0,0 +1,123 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverGoModules_GoWork(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"go.work":          "go 1.24\n\nuse (\n\t.\n\t./libs/auth\n\t../outside\n)\n",
+		"go.mod":           "module example.com/acme\n",
+		"libs/auth/go.mod": "module example.com/acme/libs/auth\n",
+		"libs/db/go.mod":   "module example.com/acme/libs/db\n",
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverGoModules(files, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{Name: "libs/auth", TagPrefix: "libs/auth/v", Paths: []string{"libs/auth"}},
+	}, components)
+}
+
+func TestDiscoverGoModules_NestedGoModFiles(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"go.mod":                    "module example.com/acme\n",
+		"tools/go.mod":              "module example.com/acme/tools\n",
+		"libs/auth/go.mod":          "module example.com/acme/libs/auth\n",
+		"libs/auth/testdata/go.mod": "module example.com/fixture\n",
+		".github/go.mod":            "module example.com/hidden\n",
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverGoModules(files, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{Name: "libs/auth", TagPrefix: "libs/auth/v", Paths: []string{"libs/auth"}},
+		{Name: "tools", TagPrefix: "tools/v", Paths: []string{"tools"}},
+	}, components)
+}
+
+func TestDiscoverGoModules_NestedModuleDirectories(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"libs/auth/go.mod":              "module example.com/acme/libs/auth\n",
+		"libs/auth/sub/go.mod":          "module example.com/acme/libs/auth/sub\n",
+		"libs/auth/sub/vendored/go.mod": "module example.com/acme/libs/auth/sub/vendored\n",
+		"libs/auth/v2/go.mod":           "module example.com/acme/libs/auth/v2\n",
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverGoModules(files, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{
+			Name:      "libs/auth",
+			TagPrefix: "libs/auth/v",
+			Paths:     []string{"libs/auth", "!libs/auth/sub", "!libs/auth/sub/vendored", "!libs/auth/v2"},
+		},
+		{
+			Name:      "libs/auth/sub",
+			TagPrefix: "libs/auth/sub/v",
+			Paths:     []string{"libs/auth/sub", "!libs/auth/sub/vendored"},
+		},
+		{
+			Name:      "libs/auth/sub/vendored",
+			TagPrefix: "libs/auth/sub/vendored/v",
+			Paths:     []string{"libs/auth/sub/vendored"},
+		},
+		{Name: "libs/auth/v2", TagPrefix: "libs/auth/v", Paths: []string{"libs/auth/v2"}},
+	}, components)
+}
+
+func TestDiscoverGoModules_MajorVersionSubdirectory(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"libs/auth/v2/go.mod": "module example.com/acme/libs/auth/v2\n",
+		"libs/v2/go.mod":      "module example.com/acme/libs/v2/core\n",
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverGoModules(files, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{Name: "libs/auth/v2", TagPrefix: "libs/auth/v", Paths: []string{"libs/auth/v2"}},
+		{Name: "libs/v2", TagPrefix: "libs/v2/v", Paths: []string{"libs/v2"}},
+	}, components)
+}
+
+func TestDiscoverGoModules_MissingModuleDirective(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{"libs/auth/go.mod": "go 1.24\n"}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	_, err = discoverGoModules(files, paths)
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
+
+func TestDiscoverGoModules_InvalidGoWork(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{"go.work": "use (\n"}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	_, err = discoverGoModules(files, paths)
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
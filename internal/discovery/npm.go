@@ -0,0 +1,116 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/erNail/verscout/internal/semverutils"
+)
+
+// packageJSON holds the fields of a package.json used for discovery.
+type packageJSON struct {
+	Name string `json:"name"`
+	// Workspaces is either a list of globs, or an object with the globs in "packages".
+	Workspaces json.RawMessage `json:"workspaces"`
+}
+
+// discoverNPMWorkspaces discovers the packages of the workspaces in the root package.json.
+// The tag prefixes follow the convention of Lerna and Changesets, e.g. "@acme/ui@" for "@acme/ui@1.2.3".
+// Globs starting with "!" exclude packages.
+func discoverNPMWorkspaces(files Files, paths []string) ([]semverutils.Component, error) {
+	if !slices.Contains(paths, "package.json") {
+		return nil, nil
+	}
+
+	rootPackage, err := readPackageJSON(files, "package.json")
+	if err != nil {
+		return nil, err
+	}
+
+	globs, err := workspaceGlobs(rootPackage.Workspaces)
+	if err != nil {
+		return nil, err
+	}
+
+	var includedGlobs, excludedGlobs []string
+
+	for _, glob := range globs {
+		if excludedGlob, excluded := strings.CutPrefix(glob, "!"); excluded {
+			excludedGlobs = append(excludedGlobs, excludedGlob)
+		} else {
+			includedGlobs = append(includedGlobs, glob)
+		}
+	}
+
+	dirs, err := filterWorkspaceDirs(manifestDirs(paths, "package.json"), includedGlobs, excludedGlobs)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]semverutils.Component, 0, len(dirs))
+
+	for _, dir := range dirs {
+		workspacePackage, err := readPackageJSON(files, path.Join(dir, "package.json"))
+		if err != nil {
+			return nil, err
+		}
+
+		name := workspacePackage.Name
+		if name == "" {
+			name = dir
+		}
+
+		components = append(components, semverutils.Component{
+			Name:      name,
+			TagPrefix: name + "@",
+			Paths:     []string{dir},
+		})
+	}
+
+	return componentsByDir(components), nil
+}
+
+// readPackageJSON reads and parses the package.json at the path.
+func readPackageJSON(files Files, filePath string) (packageJSON, error) {
+	content, err := files.ReadFile(filePath)
+	if err != nil {
+		return packageJSON{}, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	var manifest packageJSON
+
+	err = json.Unmarshal(content, &manifest)
+	if err != nil {
+		return packageJSON{}, fmt.Errorf("%w: %s: %w", ErrInvalidManifest, filePath, err)
+	}
+
+	return manifest, nil
+}
+
+// workspaceGlobs returns the globs of the workspaces field of a package.json.
+func workspaceGlobs(workspaces json.RawMessage) ([]string, error) {
+	if len(workspaces) == 0 {
+		return nil, nil
+	}
+
+	var globs []string
+
+	err := json.Unmarshal(workspaces, &globs)
+	if err == nil {
+		return globs, nil
+	}
+
+	var workspacesObject struct {
+		Packages []string `json:"packages"`
+	}
+
+	err = json.Unmarshal(workspaces, &workspacesObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: package.json: workspaces: %w", ErrInvalidManifest, err)
+	}
+
+	return workspacesObject.Packages, nil
+}
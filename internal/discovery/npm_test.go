@@ -0,0 +1,74 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverNPMWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"package.json":                 `{"workspaces": ["packages/*", "!packages/legacy", "./apps/web"]}`,
+		"packages/ui/package.json":     `{"name": "@acme/ui"}`,
+		"packages/legacy/package.json": `{"name": "@acme/legacy"}`,
+		"packages/ui/src/package.json": `{"name": "nested"}`,
+		"apps/web/package.json":        `{}`,
+		"apps/docs/package.json":       `{"name": "docs"}`,
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverNPMWorkspaces(files, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{Name: "apps/web", TagPrefix: "apps/web@", Paths: []string{"apps/web"}},
+		{Name: "@acme/ui", TagPrefix: "@acme/ui@", Paths: []string{"packages/ui"}},
+	}, components)
+}
+
+func TestDiscoverNPMWorkspaces_PackagesObject(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"package.json":             `{"workspaces": {"packages": ["packages/*"], "nohoist": ["**/react"]}}`,
+		"packages/ui/package.json": `{"name": "@acme/ui"}`,
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverNPMWorkspaces(files, paths)
+	require.NoError(t, err)
+	assert.Equal(t, []semverutils.Component{
+		{Name: "@acme/ui", TagPrefix: "@acme/ui@", Paths: []string{"packages/ui"}},
+	}, components)
+}
+
+func TestDiscoverNPMWorkspaces_NoWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{
+		"package.json":             `{"name": "acme"}`,
+		"packages/ui/package.json": `{"name": "@acme/ui"}`,
+	}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	components, err := discoverNPMWorkspaces(files, paths)
+	require.NoError(t, err)
+	assert.Empty(t, components)
+}
+
+func TestDiscoverNPMWorkspaces_InvalidPackageJSON(t *testing.T) {
+	t.Parallel()
+
+	files := testFiles{"package.json": `{"workspaces": "packages/*"}`}
+	paths, err := files.Paths()
+	require.NoError(t, err)
+
+	_, err = discoverNPMWorkspaces(files, paths)
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
@@ -0,0 +1,288 @@
+package gitutils
+
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// walkSlop is the number of additional commits to walk once only released commits are left in the queue.
+// It compensates for commits with a skewed committer time, similar to `git log`.
+const walkSlop = 5
+
+// maxReleasedCommits is the maximum number of released commits a single walk can handle.
+// Each released commit needs its own bit in walkFlags.
+const maxReleasedCommits = 63
+
+// walkFlags mark from which sides of the walk a commit was reached.
+// The lowest bit marks commits reachable from the start of the walk, e.g. HEAD.
+// Each of the remaining bits marks commits reachable from one of the released commits, e.g. the latest tags.
+type walkFlags uint64
+
+// reachableFromHead marks commits that are reachable from the start of the walk, e.g. HEAD.
+const reachableFromHead walkFlags = 1
+
+// reachableFromRelease returns the flag marking commits reachable from the released commit with the given index.
+func reachableFromRelease(index int) walkFlags {
+	return reachableFromHead << (index + 1)
+}
+
+// walkEntry holds the state of a single commit during the walk.
+type walkEntry struct {
+	commit   *object.Commit
+	flags    walkFlags
+	queued   bool
+	expanded bool
+}
+
+// commitWalker collects the commits reachable from a start commit that are not reachable from a released commit.
+// Several released commits can be handled in the same walk, e.g. the latest tags of several components.
+// All sides are walked at the same time, ordered by committer time, newest first.
+// Each commit is marked with the sides it was reached from, and released marks are propagated to its ancestors.
+// The walk ends as soon as only commits released by all released commits are left in the queue,
+// since all older commits are reachable from the released commits as well.
+// This way, the history behind the released commits is never walked completely.
+type commitWalker struct {
+	repo        *git.Repository
+	firstParent bool
+	entries     map[plumbing.Hash]*walkEntry
+	queue       walkQueue
+	// released holds the flags of all released commits. A commit is released once it has all of them.
+	released          walkFlags
+	unreleasedInQueue int
+	visited           []*walkEntry
+}
+
+func newCommitWalker(repo *git.Repository, firstParent bool) *commitWalker {
+	return &commitWalker{
+		repo:        repo,
+		firstParent: firstParent,
+		entries:     make(map[plumbing.Hash]*walkEntry),
+	}
+}
+
+// walk returns the commits reachable from the start commit that are not reachable from the released commit,
+// ordered by committer time, newest first.
+func (walker *commitWalker) walk(start *object.Commit, released *object.Commit) ([]*object.Commit, error) {
+	commits, err := walker.walkMany(start, []plumbing.Hash{released.Hash})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits[0], nil
+}
+
+// walkMany returns the commits reachable from the start commit that are not reachable from the released commit,
+// for each of the released commits, ordered by committer time, newest first.
+// At most maxReleasedCommits released commits can be given.
+func (walker *commitWalker) walkMany(start *object.Commit, released []plumbing.Hash) ([][]*object.Commit, error) {
+	if len(released) > maxReleasedCommits {
+		return nil, fmt.Errorf("cannot walk more than %d released commits at once", maxReleasedCommits)
+	}
+
+	for index := range released {
+		walker.released |= reachableFromRelease(index)
+	}
+
+	walker.push(start, reachableFromHead)
+
+	for index, hash := range released {
+		err := walker.reach(hash, reachableFromRelease(index))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slop := walkSlop
+
+	for walker.queue.Len() > 0 {
+		if walker.unreleasedInQueue == 0 {
+			if slop == 0 {
+				break
+			}
+
+			slop--
+		} else {
+			slop = walkSlop
+		}
+
+		entry, _ := heap.Pop(&walker.queue).(*walkEntry)
+		entry.queued = false
+		entry.expanded = true
+
+		if !walker.isReleased(entry.flags) {
+			walker.unreleasedInQueue--
+		}
+
+		walker.visited = append(walker.visited, entry)
+
+		err := walker.expand(entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits := make([][]*object.Commit, len(released))
+
+	for _, entry := range walker.visited {
+		if entry.flags&reachableFromHead == 0 {
+			continue
+		}
+
+		for index := range released {
+			if entry.flags&reachableFromRelease(index) == 0 {
+				commits[index] = append(commits[index], entry.commit)
+			}
+		}
+	}
+
+	return commits, nil
+}
+
+// isReleased reports whether the flags contain the flags of all released commits.
+func (walker *commitWalker) isReleased(flags walkFlags) bool {
+	return flags&walker.released == walker.released
+}
+
+// expand passes the flags of a commit on to its parents.
+// In first-parent mode, a commit only passes reachableFromHead on to its first parent.
+// Released marks are always passed on to all parents, since everything behind a released commit is released.
+func (walker *commitWalker) expand(entry *walkEntry) error {
+	for index, parentHash := range entry.commit.ParentHashes {
+		flags := entry.flags
+		if walker.firstParent && index > 0 {
+			flags &^= reachableFromHead
+		}
+
+		if flags == 0 {
+			continue
+		}
+
+		err := walker.reach(parentHash, flags)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reach adds the flags to the commit with the given hash, queueing the commit if it was not reached before.
+func (walker *commitWalker) reach(hash plumbing.Hash, flags walkFlags) error {
+	entry, found := walker.entries[hash]
+	if !found {
+		commit, err := walker.repo.CommitObject(hash)
+		if err != nil {
+			return fmt.Errorf("failed to get commit object %s: %w", hash, err)
+		}
+
+		walker.push(commit, flags)
+
+		return nil
+	}
+
+	newReleased := flags & walker.released &^ entry.flags
+	if newReleased != 0 {
+		err := walker.markReleased(entry, newReleased)
+		if err != nil {
+			return err
+		}
+	}
+
+	entry.flags |= flags
+
+	return nil
+}
+
+// markReleased adds released marks to a commit that was reached before, together with all of its ancestors
+// that were reached before. Parents of already expanded commits that were not reached yet are queued with the marks,
+// so their ancestors are marked once they are reached from the start commit.
+func (walker *commitWalker) markReleased(entry *walkEntry, released walkFlags) error {
+	stack := []*walkEntry{entry}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current.flags&released == released {
+			continue
+		}
+
+		wasReleased := walker.isReleased(current.flags)
+		current.flags |= released
+
+		if current.queued && !wasReleased && walker.isReleased(current.flags) {
+			walker.unreleasedInQueue--
+		}
+
+		if !current.expanded {
+			// The flags are passed on to the parents once the commit is expanded
+			continue
+		}
+
+		for _, parentHash := range current.commit.ParentHashes {
+			parent, found := walker.entries[parentHash]
+			if found {
+				stack = append(stack, parent)
+
+				continue
+			}
+
+			err := walker.reach(parentHash, released)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (walker *commitWalker) push(commit *object.Commit, flags walkFlags) {
+	entry := &walkEntry{commit: commit, flags: flags, queued: true}
+	walker.entries[commit.Hash] = entry
+
+	if !walker.isReleased(flags) {
+		walker.unreleasedInQueue++
+	}
+
+	heap.Push(&walker.queue, entry)
+}
+
+// walkQueue is a priority queue of commits, ordered by committer time, newest first.
+type walkQueue []*walkEntry
+
+func (queue walkQueue) Len() int {
+	return len(queue)
+}
+
+func (queue walkQueue) Less(i, j int) bool {
+	timeI, timeJ := queue[i].commit.Committer.When, queue[j].commit.Committer.When
+	if !timeI.Equal(timeJ) {
+		return timeI.After(timeJ)
+	}
+
+	// Keep the order deterministic for commits with the same committer time
+	return queue[i].commit.Hash.String() < queue[j].commit.Hash.String()
+}
+
+func (queue walkQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+}
+
+func (queue *walkQueue) Push(entry any) {
+	walkEntry, _ := entry.(*walkEntry)
+	*queue = append(*queue, walkEntry)
+}
+
+func (queue *walkQueue) Pop() any {
+	old := *queue
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*queue = old[:len(old)-1]
+
+	return entry
+}
@@ -0,0 +1,382 @@
+package gitutils
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// walkByFullHistory is the reference implementation for commitWalker.
+// It collects all ancestors of the released commit first, and then walks from the start commit, skipping them.
+func walkByFullHistory(
+	repo *git.Repository,
+	start *object.Commit,
+	released *object.Commit,
+	firstParent bool,
+) ([]*object.Commit, error) {
+	releasedCommits := make(map[plumbing.Hash]bool)
+
+	err := object.NewCommitPreorderIter(released, nil, nil).ForEach(func(commit *object.Commit) error {
+		releasedCommits[commit.Hash] = true
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+
+	if firstParent {
+		for commit := start; commit != nil && !releasedCommits[commit.Hash]; {
+			commits = append(commits, commit)
+
+			if commit.NumParents() == 0 {
+				break
+			}
+
+			commit, err = repo.CommitObject(commit.ParentHashes[0])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return commits, nil
+	}
+
+	err = object.NewCommitIterCTime(start, releasedCommits, nil).ForEach(func(commit *object.Commit) error {
+		commits = append(commits, commit)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+func commitHashes(commits []*object.Commit) []plumbing.Hash {
+	hashes := make([]plumbing.Hash, 0, len(commits))
+	for _, commit := range commits {
+		hashes = append(hashes, commit.Hash)
+	}
+
+	return hashes
+}
+
+// createRandomHistory creates a random history with the given number of commits and strictly increasing
+// committer times. Each commit has one or two parents, picked among the recent commits.
+func createRandomHistory(t *testing.T, repo *git.Repository, random *rand.Rand, commitCount int) []plumbing.Hash {
+	t.Helper()
+
+	const parentWindow = 8
+
+	startTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	hashes := make([]plumbing.Hash, 0, commitCount)
+
+	for index := range commitCount {
+		var parentHashes []plumbing.Hash
+
+		if index > 0 {
+			low := max(0, index-parentWindow)
+			parentHashes = append(parentHashes, hashes[low+random.Intn(index-low)])
+
+			if random.Intn(3) == 0 {
+				secondParent := hashes[low+random.Intn(index-low)]
+				if secondParent != parentHashes[0] {
+					parentHashes = append(parentHashes, secondParent)
+				}
+			}
+		}
+
+		commitHash, err := CreateTestCommitObject(
+			repo,
+			"chore: Random commit",
+			parentHashes,
+			startTime.Add(time.Duration(index)*time.Minute),
+		)
+		require.NoError(t, err)
+
+		hashes = append(hashes, commitHash)
+	}
+
+	return hashes
+}
+
+func TestCommitWalker_MatchesFullHistoryWalk(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(42)) //nolint:gosec // Deterministic test data
+
+	for range 20 {
+		repo, err := CreateTestRepo()
+		require.NoError(t, err)
+
+		hashes := createRandomHistory(t, repo, random, 60)
+
+		for range 10 {
+			start, err := repo.CommitObject(hashes[len(hashes)-1-random.Intn(10)])
+			require.NoError(t, err)
+			released, err := repo.CommitObject(hashes[random.Intn(len(hashes))])
+			require.NoError(t, err)
+
+			for _, firstParent := range []bool{false, true} {
+				expected, err := walkByFullHistory(repo, start, released, firstParent)
+				require.NoError(t, err)
+
+				actual, err := newCommitWalker(repo, firstParent).walk(start, released)
+				require.NoError(t, err)
+
+				assert.Equal(t, commitHashes(expected), commitHashes(actual))
+			}
+		}
+	}
+}
+
+func TestCommitWalker_WalkManyMatchesFullHistoryWalk(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(42)) //nolint:gosec // Deterministic test data
+
+	for range 20 {
+		repo, err := CreateTestRepo()
+		require.NoError(t, err)
+
+		hashes := createRandomHistory(t, repo, random, 60)
+
+		for range 10 {
+			start, err := repo.CommitObject(hashes[len(hashes)-1-random.Intn(10)])
+			require.NoError(t, err)
+
+			released := make([]plumbing.Hash, 1+random.Intn(5))
+			for index := range released {
+				released[index] = hashes[random.Intn(len(hashes))]
+			}
+
+			for _, firstParent := range []bool{false, true} {
+				actual, err := newCommitWalker(repo, firstParent).walkMany(start, released)
+				require.NoError(t, err)
+				require.Len(t, actual, len(released))
+
+				for index, releasedHash := range released {
+					releasedCommit, err := repo.CommitObject(releasedHash)
+					require.NoError(t, err)
+
+					expected, err := walkByFullHistory(repo, start, releasedCommit, firstParent)
+					require.NoError(t, err)
+
+					assert.Equal(t, commitHashes(expected), commitHashes(actual[index]))
+				}
+			}
+		}
+	}
+}
+
+func TestCommitWalker_WalkManyTooManyReleasedCommits(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	hash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	start, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+
+	released := make([]plumbing.Hash, maxReleasedCommits+1)
+	for index := range released {
+		released[index] = hash
+	}
+
+	_, err = newCommitWalker(repo, false).walkMany(start, released)
+	require.Error(t, err)
+}
+
+func TestCommitWalker_StopsAtReleasedCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	mainline, err := CreateTestHistory(repo, 1000, 4)
+	require.NoError(t, err)
+
+	start, err := repo.CommitObject(mainline[len(mainline)-1])
+	require.NoError(t, err)
+	released, err := repo.CommitObject(mainline[len(mainline)-11])
+	require.NoError(t, err)
+
+	walker := newCommitWalker(repo, false)
+	commits, err := walker.walk(start, released)
+	require.NoError(t, err)
+
+	// 10 mainline commits, and 2 feature commits for each of the 2 or 3 merge commits among them
+	assert.GreaterOrEqual(t, len(commits), 14)
+	assert.LessOrEqual(t, len(commits), 16)
+	assert.Less(t, len(walker.visited), 50)
+}
+
+func TestCommitWalker_ReleasedCommitNotReachable(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	baseTime := time.Now()
+	rootHash, err := CreateTestCommitObject(repo, "Root", nil, baseTime)
+	require.NoError(t, err)
+	startHash, err := CreateTestCommitObject(repo, "Start", []plumbing.Hash{rootHash}, baseTime.Add(time.Hour))
+	require.NoError(t, err)
+	releasedHash, err := CreateTestCommitObject(repo, "Released", nil, baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	start, err := repo.CommitObject(startHash)
+	require.NoError(t, err)
+	released, err := repo.CommitObject(releasedHash)
+	require.NoError(t, err)
+
+	commits, err := newCommitWalker(repo, false).walk(start, released)
+	require.NoError(t, err)
+	assert.Equal(t, []plumbing.Hash{startHash, rootHash}, commitHashes(commits))
+}
+
+func benchmarkCommitWalk(b *testing.B, mergeInterval int, firstParent bool) {
+	b.Helper()
+
+	const (
+		historySize         = 20000
+		commitsSinceRelease = 20
+	)
+
+	repo, err := CreateTestRepo()
+	require.NoError(b, err)
+	mainline, err := CreateTestHistory(repo, historySize, mergeInterval)
+	require.NoError(b, err)
+
+	start, err := repo.CommitObject(mainline[len(mainline)-1])
+	require.NoError(b, err)
+	released, err := repo.CommitObject(mainline[len(mainline)-1-commitsSinceRelease])
+	require.NoError(b, err)
+
+	b.Run("walker", func(b *testing.B) {
+		for range b.N {
+			_, err := newCommitWalker(repo, firstParent).walk(start, released)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("full-history", func(b *testing.B) {
+		for range b.N {
+			_, err := walkByFullHistory(repo, start, released, firstParent)
+			require.NoError(b, err)
+		}
+	})
+}
+
+func BenchmarkCommitWalk_LinearHistory(b *testing.B) {
+	benchmarkCommitWalk(b, 0, false)
+}
+
+func BenchmarkCommitWalk_MergeHistory(b *testing.B) {
+	benchmarkCommitWalk(b, 3, false)
+}
+
+func BenchmarkCommitWalk_MergeHistoryFirstParent(b *testing.B) {
+	benchmarkCommitWalk(b, 3, true)
+}
+
+// reachableByFullHistory is the reference implementation for tagReachability.
+// It walks the history from the start commit until all tag commits are found.
+func reachableByFullHistory(start *object.Commit, tagCommits []plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	pending := make(map[plumbing.Hash]bool, len(tagCommits))
+	for _, hash := range tagCommits {
+		pending[hash] = true
+	}
+
+	reachable := make(map[plumbing.Hash]bool, len(tagCommits))
+
+	err := object.NewCommitPreorderIter(start, nil, nil).ForEach(func(commit *object.Commit) error {
+		if pending[commit.Hash] {
+			reachable[commit.Hash] = true
+			delete(pending, commit.Hash)
+		}
+
+		if len(pending) == 0 {
+			return storer.ErrStop
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reachable, nil
+}
+
+func BenchmarkTagReachability_UnreachableTag(b *testing.B) {
+	const (
+		historySize         = 20000
+		commitsSinceRelease = 20
+		releaseBranchFork   = 30
+	)
+
+	repo, err := CreateTestRepo()
+	require.NoError(b, err)
+	mainline, err := CreateTestHistory(repo, historySize, 3)
+	require.NoError(b, err)
+
+	forkCommit, err := repo.CommitObject(mainline[len(mainline)-1-releaseBranchFork])
+	require.NoError(b, err)
+	// A tag on a release branch forked from the mainline, e.g. a backport, is not reachable from HEAD
+	releaseBranchHash, err := CreateTestCommitObject(
+		repo,
+		"fix: Backport",
+		[]plumbing.Hash{forkCommit.Hash},
+		forkCommit.Committer.When.Add(time.Second),
+	)
+	require.NoError(b, err)
+
+	start, err := repo.CommitObject(mainline[len(mainline)-1])
+	require.NoError(b, err)
+
+	releaseBranchCommit, err := repo.CommitObject(releaseBranchHash)
+	require.NoError(b, err)
+	releasedCommit, err := repo.CommitObject(mainline[len(mainline)-1-commitsSinceRelease])
+	require.NoError(b, err)
+	firstCommit, err := repo.CommitObject(mainline[0])
+	require.NoError(b, err)
+
+	tags := []TagInfo{
+		{Name: "1.0.0", Commit: firstCommit, Version: &semverutils.SemVer{Major: 1}},
+		{Name: "2.0.0", Commit: releasedCommit, Version: &semverutils.SemVer{Major: 2}},
+		{Name: "2.0.1", Commit: releaseBranchCommit, Version: &semverutils.SemVer{Major: 2, Patch: 1}},
+	}
+
+	b.Run("selection-order", func(b *testing.B) {
+		for range b.N {
+			reachability := &tagReachability{repo: repo, start: start, reachable: make(map[plumbing.Hash]bool)}
+
+			latestTag, err := reachability.selectLatestVersionTag(SemVerPrecedence, tags)
+			require.NoError(b, err)
+			require.Equal(b, "2.0.0", latestTag.Name)
+		}
+	})
+
+	b.Run("full-history", func(b *testing.B) {
+		for range b.N {
+			reachable, err := reachableByFullHistory(
+				start,
+				[]plumbing.Hash{firstCommit.Hash, releasedCommit.Hash, releaseBranchCommit.Hash},
+			)
+			require.NoError(b, err)
+			require.False(b, reachable[releaseBranchCommit.Hash])
+		}
+	})
+}
@@ -47,38 +47,54 @@ func CreateTestCommit(repo *git.Repository, message, fileName, content string, t
 		return plumbing.ZeroHash, fmt.Errorf("failed to commit: %w", err)
 	}
 
+	err = StageTestFile(repo, fileName, content)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	commitHash, err := worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "author@test.com",
+			When:  time,
+		},
+	})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// StageTestFile writes the content to the file in the worktree and adds it to the index.
+// It can be used to include changes in the next merge commit created with CreateTestMergeCommit.
+func StageTestFile(repo *git.Repository, fileName, content string) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
 	file, err := worktree.Filesystem.Create(fileName)
 	if err != nil {
-		return plumbing.ZeroHash, fmt.Errorf("failed to create file: %w", err)
+		return fmt.Errorf("failed to create file: %w", err)
 	}
 
 	_, err = file.Write([]byte(content))
 	if err != nil {
-		return plumbing.ZeroHash, fmt.Errorf("failed to write file: %w", err)
+		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	err = file.Close()
 	if err != nil {
-		return plumbing.ZeroHash, fmt.Errorf("failed to close file: %w", err)
+		return fmt.Errorf("failed to close file: %w", err)
 	}
 
 	_, err = worktree.Add(fileName)
 	if err != nil {
-		return plumbing.ZeroHash, fmt.Errorf("failed to add file: %w", err)
+		return fmt.Errorf("failed to add file: %w", err)
 	}
 
-	commitHash, err := worktree.Commit(message, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "Test Author",
-			Email: "author@test.com",
-			When:  time,
-		},
-	})
-	if err != nil {
-		return plumbing.ZeroHash, fmt.Errorf("failed to commit: %w", err)
-	}
-
-	return commitHash, nil
+	return nil
 }
 
 // CreateTag creates a lightweight tag in the repository pointing to the given commit.
@@ -117,3 +133,184 @@ func CreateAnnotatedTag(
 
 	return tagHash, nil
 }
+
+// CheckoutNewBranch creates a new branch pointing to the given commit and checks it out.
+func CheckoutNewBranch(repo *git.Repository, branchName string, commitHash plumbing.Hash) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branchName),
+		Hash:   commitHash,
+		Create: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to checkout new branch %s: %w", branchName, err)
+	}
+
+	return nil
+}
+
+// CheckoutBranch checks out an existing branch.
+func CheckoutBranch(repo *git.Repository, branchName string) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branchName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to checkout branch %s: %w", branchName, err)
+	}
+
+	return nil
+}
+
+// CreateTestMergeCommit creates a new merge commit on the current branch with the specified message and timestamp.
+// The first parent is HEAD, the second parent is the given commit. The tree of HEAD is kept as is,
+// apart from files staged with StageTestFile.
+func CreateTestMergeCommit(
+	repo *git.Repository,
+	message string,
+	mergedCommitHash plumbing.Hash,
+	time time.Time,
+) (plumbing.Hash, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	commitHash, err := worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Author",
+			Email: "author@test.com",
+			When:  time,
+		},
+		Parents:           []plumbing.Hash{head.Hash(), mergedCommitHash},
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to commit merge: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// CreateTestCommitObject stores a new commit with the given parents directly in the repository storage,
+// without touching the worktree or any references. The commit points to an empty tree.
+// This is considerably faster than CreateTestCommit, e.g. for creating large synthetic histories.
+func CreateTestCommitObject(
+	repo *git.Repository,
+	message string,
+	parentHashes []plumbing.Hash,
+	time time.Time,
+) (plumbing.Hash, error) {
+	treeObject := repo.Storer.NewEncodedObject()
+
+	err := (&object.Tree{}).Encode(treeObject)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode tree: %w", err)
+	}
+
+	treeHash, err := repo.Storer.SetEncodedObject(treeObject)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to store tree: %w", err)
+	}
+
+	signature := object.Signature{Name: "Test Author", Email: "author@test.com", When: time}
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: parentHashes,
+	}
+
+	commitObject := repo.Storer.NewEncodedObject()
+
+	err = commit.Encode(commitObject)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode commit: %w", err)
+	}
+
+	commitHash, err := repo.Storer.SetEncodedObject(commitObject)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to store commit: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// CreateTestHistory creates a synthetic history with the given number of mainline commits on the master branch.
+// Every mergeInterval-th mainline commit is a merge commit of a feature branch with two commits,
+// forked from the previous mainline commit. A mergeInterval of 0 creates a linear history.
+// The committer times are strictly increasing. HEAD points to the last mainline commit.
+// Returns the hashes of the mainline commits, oldest first.
+func CreateTestHistory(repo *git.Repository, mainlineCommits int, mergeInterval int) ([]plumbing.Hash, error) {
+	startTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	mainline := make([]plumbing.Hash, 0, mainlineCommits)
+	tick := 0
+
+	nextTime := func() time.Time {
+		tick++
+
+		return startTime.Add(time.Duration(tick) * time.Minute)
+	}
+
+	for index := range mainlineCommits {
+		var parentHashes []plumbing.Hash
+		if index > 0 {
+			parentHashes = []plumbing.Hash{mainline[index-1]}
+		}
+
+		message := fmt.Sprintf("chore: Mainline commit %d", index)
+
+		if index > 0 && mergeInterval > 0 && index%mergeInterval == 0 {
+			featureHash := mainline[index-1]
+
+			for featureIndex := range 2 {
+				var err error
+
+				featureHash, err = CreateTestCommitObject(
+					repo,
+					fmt.Sprintf("chore: Feature commit %d.%d", index, featureIndex),
+					[]plumbing.Hash{featureHash},
+					nextTime(),
+				)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			parentHashes = append(parentHashes, featureHash)
+			message = fmt.Sprintf("chore: Merge feature %d", index)
+		}
+
+		commitHash, err := CreateTestCommitObject(repo, message, parentHashes, nextTime())
+		if err != nil {
+			return nil, err
+		}
+
+		mainline = append(mainline, commitHash)
+	}
+
+	if len(mainline) > 0 {
+		err := repo.Storer.SetReference(
+			plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), mainline[len(mainline)-1]),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set master branch: %w", err)
+		}
+	}
+
+	return mainline, nil
+}
@@ -3,6 +3,8 @@ package gitutils
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/erNail/verscout/internal/semverutils"
 	"github.com/go-git/go-git/v5"
@@ -18,13 +20,44 @@ var (
 	ErrNoValidVersionTags = errors.New("no valid version tags found")
 	// ErrNoTags indicates that no tags were found in the repository.
 	ErrNoTags = errors.New("no tags found")
+	// ErrInvalidSelectionStrategy indicates that an unknown tag selection strategy was requested.
+	ErrInvalidSelectionStrategy = errors.New("invalid tag selection strategy")
 )
 
+// TagSelectionStrategy defines how the latest version tag is selected among all version tags.
+type TagSelectionStrategy string
+
+const (
+	// SemVerPrecedence selects the tag with the highest SemVer precedence.
+	// Ties, e.g. "v1.0.0" and "1.0.0" or versions only differing in build metadata,
+	// are broken by the newest commit time, and then by the lexically greatest tag name.
+	SemVerPrecedence TagSelectionStrategy = "semver"
+	// CommitTime selects the tag pointing to the commit with the newest committer time.
+	// Ties, e.g. several tags on the same commit, are broken by the highest SemVer precedence,
+	// and then by the lexically greatest tag name.
+	CommitTime TagSelectionStrategy = "commit-time"
+)
+
+// LatestVersionTagOptions holds the options for selecting the latest version tag.
+type LatestVersionTagOptions struct {
+	// Strategy defines how the latest tag is selected. Defaults to SemVerPrecedence.
+	Strategy TagSelectionStrategy
+	// ExcludePreReleases ignores all version tags with pre-release identifiers.
+	ExcludePreReleases bool
+	// TagFormat defines which tags are version tags. Defaults to semverutils.DefaultTagFormat.
+	TagFormat *semverutils.TagFormat
+	// ReachableFrom only considers version tags pointing to the given revision or one of its ancestors,
+	// e.g. "HEAD". If empty, all version tags in the repository are considered.
+	ReachableFrom string
+}
+
 // TagInfo holds information about a git tag.
 type TagInfo struct {
 	Name   string
 	Commit *object.Commit
 	TagRef *plumbing.Reference
+	// Version is the version contained in the tag name. It is only set for version tags.
+	Version *semverutils.SemVer
 }
 
 // getCommitTimestamp retrieves the Unix timestamp of a commit given its hash.
@@ -71,7 +104,8 @@ func GetCommitFromTag(repo *git.Repository, tagRef *plumbing.Reference) (*object
 	return nil, fmt.Errorf("failed to get tag object for tag %s: %w", tagRef.Name().Short(), err)
 }
 
-// GetTagsWithAssociatedCommits returns all tags in the repository with their associated commits.
+// GetTagsWithAssociatedCommits returns all tags in the repository with their associated commits,
+// ordered by tag name.
 // Returns ErrNoTags if no tags are found.
 func GetTagsWithAssociatedCommits(repo *git.Repository) ([]TagInfo, error) {
 	var tagsInfo []TagInfo
@@ -104,101 +138,469 @@ func GetTagsWithAssociatedCommits(repo *git.Repository) ([]TagInfo, error) {
 		return nil, ErrNoTags
 	}
 
+	// The order of the tag references depends on the storage, e.g. it is random for in-memory repositories
+	slices.SortFunc(tagsInfo, func(tag, other TagInfo) int {
+		return strings.Compare(tag.Name, other.Name)
+	})
+
 	return tagsInfo, nil
 }
 
-// GetLatestVersionTag finds the most recent semantic version tag in the repository.
+// GetLatestVersionTag finds the latest semantic version tag in the repository,
+// using the selection strategy and filters of the given options.
 // Returns ErrNoValidVersionTags if no valid version tags are found.
 // Returns ErrNoTags if no tags are found in the repository.
-func GetLatestVersionTag(repo *git.Repository) (*TagInfo, error) {
+// Returns ErrInvalidSelectionStrategy if the selection strategy is unknown.
+func GetLatestVersionTag(repo *git.Repository, options LatestVersionTagOptions) (*TagInfo, error) {
+	strategy, err := validateSelectionStrategy(options.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
 	tags, err := GetTagsWithAssociatedCommits(repo)
 	if err != nil {
 		// Error type could be ErrNoTags
 		return nil, fmt.Errorf("failed to get tags with timestamps: %w", err)
 	}
 
-	var latestTag TagInfo
+	versionTags := filterTagsByOptions(tags, options.TagFormat, options)
 
-	for _, tag := range tags {
-		if semverutils.IsValidSemVerTag(tag.Name) {
-			if latestTag.Name == "" || tag.Commit.Committer.When.Unix() > latestTag.Commit.Committer.When.Unix() {
-				latestTag = tag
-			}
+	var latestTag *TagInfo
+
+	if options.ReachableFrom != "" && len(versionTags) > 0 {
+		reachability, err := newTagReachability(repo, options.ReachableFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tags reachable from %s: %w", options.ReachableFrom, err)
 		}
+
+		latestTag, err = reachability.selectLatestVersionTag(strategy, versionTags)
+		if err != nil && !errors.Is(err, ErrNoValidVersionTags) {
+			return nil, fmt.Errorf("failed to find tags reachable from %s: %w", options.ReachableFrom, err)
+		}
+	} else {
+		latestTag = selectLatestVersionTag(strategy, versionTags)
 	}
 
-	if latestTag.Name == "" {
+	if latestTag == nil {
 		return nil, ErrNoValidVersionTags
 	}
 
-	log.WithField("tag", latestTag.Name).Info("Found latest version tag")
+	log.WithFields(log.Fields{"tag": latestTag.Name, "strategy": strategy}).Info("Found latest version tag")
+
+	return latestTag, nil
+}
+
+// GetLatestVersionTags finds the latest version tag for each of the tag formats, like GetLatestVersionTag,
+// but reads the tags and walks the history only once for all tag formats.
+// The TagFormat option is ignored in favor of the given tag formats.
+// The result contains nil for each tag format without any valid version tag.
+// Returns ErrInvalidSelectionStrategy if the selection strategy is unknown.
+func GetLatestVersionTags(
+	repo *git.Repository,
+	tagFormats []*semverutils.TagFormat,
+	options LatestVersionTagOptions,
+) ([]*TagInfo, error) {
+	strategy, err := validateSelectionStrategy(options.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	latestTags := make([]*TagInfo, len(tagFormats))
+
+	tags, err := GetTagsWithAssociatedCommits(repo)
+	if errors.Is(err, ErrNoTags) {
+		return latestTags, nil
+	}
 
-	return &latestTag, nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags with timestamps: %w", err)
+	}
+
+	var reachability *tagReachability
+
+	if options.ReachableFrom != "" {
+		reachability, err = newTagReachability(repo, options.ReachableFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tags reachable from %s: %w", options.ReachableFrom, err)
+		}
+	}
+
+	for index, tagFormat := range tagFormats {
+		versionTags := filterTagsByOptions(tags, tagFormat, options)
+
+		if reachability != nil {
+			latestTags[index], err = reachability.selectLatestVersionTag(strategy, versionTags)
+			if err != nil && !errors.Is(err, ErrNoValidVersionTags) {
+				return nil, fmt.Errorf("failed to find tags reachable from %s: %w", options.ReachableFrom, err)
+			}
+		} else {
+			latestTags[index] = selectLatestVersionTag(strategy, versionTags)
+		}
+
+		if latestTags[index] != nil {
+			log.WithFields(log.Fields{"tag": latestTags[index].Name, "strategy": strategy}).
+				Info("Found latest version tag")
+		}
+	}
+
+	return latestTags, nil
+}
+
+// validateSelectionStrategy returns the given selection strategy, or SemVerPrecedence if it is empty.
+// Returns ErrInvalidSelectionStrategy if the selection strategy is unknown.
+func validateSelectionStrategy(strategy TagSelectionStrategy) (TagSelectionStrategy, error) {
+	if strategy == "" {
+		return SemVerPrecedence, nil
+	}
+
+	if strategy != SemVerPrecedence && strategy != CommitTime {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSelectionStrategy, strategy)
+	}
+
+	return strategy, nil
+}
+
+// filterTagsByOptions returns the version tags matching the tag format, with their Version set.
+// Pre-releases are skipped if the options exclude them.
+func filterTagsByOptions(
+	tags []TagInfo,
+	tagFormat *semverutils.TagFormat,
+	options LatestVersionTagOptions,
+) []TagInfo {
+	versionTags := filterVersionTags(tags, tagFormat)
+	if options.ExcludePreReleases {
+		versionTags = slices.DeleteFunc(versionTags, func(tag TagInfo) bool {
+			return tag.Version.IsPreRelease()
+		})
+	}
+
+	return versionTags
+}
+
+// selectLatestVersionTag returns the latest of the version tags according to the selection strategy,
+// or nil if there are no version tags.
+func selectLatestVersionTag(strategy TagSelectionStrategy, versionTags []TagInfo) *TagInfo {
+	var latestTag *TagInfo
+
+	for _, tag := range versionTags {
+		if latestTag == nil || compareVersionTags(strategy, &tag, latestTag) > 0 {
+			latestTag = &tag
+		}
+	}
+
+	return latestTag
+}
+
+// tagReachability checks whether tag commits are reachable from a revision, caching the results.
+type tagReachability struct {
+	repo      *git.Repository
+	start     *object.Commit
+	reachable map[plumbing.Hash]bool
+}
+
+func newTagReachability(repo *git.Repository, revision string) (*tagReachability, error) {
+	start, err := resolveCommit(repo, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tagReachability{repo: repo, start: start, reachable: make(map[plumbing.Hash]bool)}, nil
+}
+
+// selectLatestVersionTag returns the latest of the version tags pointing to the revision or one of its ancestors,
+// see selectLatestVersionTag. The tags are checked in the order of selection, so the check stops at the latest
+// reachable tag, and older tags, e.g. the first tag near the root commit, are never checked.
+// Returns ErrNoValidVersionTags if none of the tags is reachable.
+func (reachability *tagReachability) selectLatestVersionTag(
+	strategy TagSelectionStrategy,
+	versionTags []TagInfo,
+) (*TagInfo, error) {
+	candidates := slices.Clone(versionTags)
+	// Stable, so tags that are equal for the strategy keep the order selectLatestVersionTag prefers
+	slices.SortStableFunc(candidates, func(tag TagInfo, other TagInfo) int {
+		return compareVersionTags(strategy, &other, &tag)
+	})
+
+	for _, candidate := range candidates {
+		reachable, err := reachability.isReachable(candidate.Commit.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if reachable {
+			return &candidate, nil
+		}
+
+		log.WithFields(log.Fields{"tag": candidate.Name, "revision": reachability.start.Hash}).
+			Debug("Ignoring unreachable tag")
+	}
+
+	return nil, ErrNoValidVersionTags
+}
+
+// isReachable reports whether the commit is the start commit or one of its ancestors.
+// The commit is treated as a released commit of a commitWalker, so the walk from the start commit stops
+// at the merge base of both commits, instead of walking the whole history if the commit is not reachable.
+func (reachability *tagReachability) isReachable(hash plumbing.Hash) (bool, error) {
+	reachable, found := reachability.reachable[hash]
+	if found {
+		return reachable, nil
+	}
+
+	walker := newCommitWalker(reachability.repo, false)
+
+	_, err := walker.walkMany(reachability.start, []plumbing.Hash{hash})
+	if err != nil {
+		return false, fmt.Errorf("failed to walk commits: %w", err)
+	}
+
+	entry, found := walker.entries[hash]
+	reachable = found && entry.flags&reachableFromHead != 0
+	reachability.reachable[hash] = reachable
+
+	return reachable, nil
+}
+
+// resolveCommit resolves a revision like "HEAD", a branch, a tag or a commit hash to its commit.
+func resolveCommit(repo *git.Repository, revision string) (*object.Commit, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %s: %w", revision, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit object for revision %s: %w", revision, err)
+	}
+
+	return commit, nil
+}
+
+// compareVersionTags compares two version tags according to the selection strategy.
+// It returns a positive number if tag should be preferred over other, and a negative number otherwise.
+// See TagSelectionStrategy for the tie-breaking rules.
+func compareVersionTags(strategy TagSelectionStrategy, tag *TagInfo, other *TagInfo) int {
+	byPrecedence := tag.Version.Compare(other.Version)
+	byCommitTime := tag.Commit.Committer.When.Compare(other.Commit.Committer.When)
+
+	first, second := byPrecedence, byCommitTime
+	if strategy == CommitTime {
+		first, second = byCommitTime, byPrecedence
+	}
+
+	if first != 0 {
+		return first
+	}
+
+	if second != 0 {
+		return second
+	}
+
+	return strings.Compare(tag.Name, other.Name)
+}
+
+// filterVersionTags returns the tags matching the tag format, with their Version set.
+// If the tag format is nil, semverutils.DefaultTagFormat is used.
+func filterVersionTags(tags []TagInfo, tagFormat *semverutils.TagFormat) []TagInfo {
+	if tagFormat == nil {
+		tagFormat = semverutils.DefaultTagFormat
+	}
+
+	versionTags := make([]TagInfo, 0, len(tags))
+
+	for _, tag := range tags {
+		semVer, err := tagFormat.ExtractVersion(tag.Name)
+		if err != nil {
+			continue
+		}
+
+		tag.Version = semVer
+		versionTags = append(versionTags, tag)
+	}
+
+	return versionTags
+}
+
+// GetVersions returns the semantic versions of all tags in the repository matching the tag format.
+// If the tag format is nil, semverutils.DefaultTagFormat is used.
+// Returns ErrNoTags if no tags are found.
+func GetVersions(repo *git.Repository, tagFormat *semverutils.TagFormat) ([]semverutils.SemVer, error) {
+	tags, err := GetTagsWithAssociatedCommits(repo)
+	if err != nil {
+		// Error type could be ErrNoTags
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	versionTags := filterVersionTags(tags, tagFormat)
+	versions := make([]semverutils.SemVer, 0, len(versionTags))
+
+	for _, tag := range versionTags {
+		versions = append(versions, *tag.Version)
+	}
+
+	return versions, nil
 }
 
 // GetLatestVersion returns the latest semantic version as a SemVer struct.
 // Returns ErrNoTags if no tags are found.
 // Returns ErrNoValidVersionTags if no valid version tags are found.
-func GetLatestVersion(repo *git.Repository) (*semverutils.SemVer, error) {
-	latestTag, err := GetLatestVersionTag(repo)
+func GetLatestVersion(repo *git.Repository, options LatestVersionTagOptions) (*semverutils.SemVer, error) {
+	latestTag, err := GetLatestVersionTag(repo, options)
 	if err != nil {
 		// Error type could be ErrNoValidVersionTags or ErrNoTags
 		return nil, fmt.Errorf("failed to get latest version tag: %w", err)
 	}
 
-	semVer, err := semverutils.ExtractSemVerStruct(latestTag.Name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract semver struct: %w", err)
-	}
+	return latestTag.Version, nil
+}
 
-	return semVer, nil
+// CommitOptions holds the options for collecting commits.
+type CommitOptions struct {
+	// From is the branch, tag or commit to collect the commits from, e.g. "main". Defaults to HEAD.
+	From string
+	// FirstParent only follows the first parent of merge commits, like `git log --first-parent`.
+	// Commits that were merged in from other branches are skipped, only the merge commits themselves are collected.
+	FirstParent bool
+	// Paths only collects commits that change files matching one of the globs, like `git log -- <path>`.
+	// See PathFilter for the glob syntax. If empty, all commits are collected.
+	Paths []string
 }
 
-// GetCommitsSinceCommitHash returns all commits made after the specified commit hash.
-// Returns ErrNoCommitsFound if no commits are found.
+// GetCommitsSinceCommitHash returns all commits reachable from HEAD, or the From option,
+// that are not reachable from the specified commit, similar to `git log <commit>..HEAD`.
+// This also holds for histories with merge commits:
+// Commits merged after the specified commit are included, even if they were created before it,
+// and commits already contained in the specified commit are excluded, even if they were created after it.
+// The history is only walked until every path from HEAD has reached an ancestor of the specified commit.
+// The commits are ordered by committer time, newest first.
+// Returns ErrNoCommitsFound if no commits are found, and ErrInvalidPathGlob if a path glob is malformed.
 func GetCommitsSinceCommitHash(
 	repo *git.Repository,
 	commitHash plumbing.Hash,
+	options CommitOptions,
 ) ([]*object.Commit, error) {
-	ref, err := repo.Head()
+	commits, err := GetCommitsForRanges(repo, []CommitRange{{Since: commitHash, Paths: options.Paths}}, options)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+		return nil, err
 	}
 
-	commitIter, err := repo.Log(&git.LogOptions{
-		From:  ref.Hash(),
-		Order: git.LogOrderCommitterTime,
-	})
+	if len(commits[0]) == 0 {
+		return nil, ErrNoCommitsFound
+	}
+
+	return commits[0], nil
+}
+
+// CommitRange selects the commits since a released commit, e.g. the latest version tag of a component.
+type CommitRange struct {
+	// Since is the hash of the released commit. Commits reachable from it are excluded.
+	Since plumbing.Hash
+	// Paths only selects commits that change files matching one of the globs. See CommitOptions.Paths.
+	Paths []string
+}
+
+// GetCommitsForRanges returns the commits of each range, like GetCommitsSinceCommitHash,
+// but walks the history only once for all ranges.
+// The Paths option is ignored in favor of the paths of each range.
+// Unlike GetCommitsSinceCommitHash, an empty slice is returned for ranges without commits.
+// Returns ErrInvalidPathGlob if a path glob is malformed.
+func GetCommitsForRanges(
+	repo *git.Repository,
+	ranges []CommitRange,
+	options CommitOptions,
+) ([][]*object.Commit, error) {
+	pathFilters := make([]*PathFilter, len(ranges))
+
+	for index, commitRange := range ranges {
+		if len(commitRange.Paths) == 0 {
+			continue
+		}
+
+		pathFilter, err := NewPathFilter(commitRange.Paths)
+		if err != nil {
+			return nil, err
+		}
+
+		pathFilters[index] = pathFilter
+	}
+
+	from := options.From
+	if from == "" {
+		from = "HEAD"
+	}
+
+	headCommit, err := resolveCommit(repo, from)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commit log: %w", err)
+		return nil, err
 	}
 
-	var commits []*object.Commit
+	commitsPerReleasedCommit, err := walkReleasedCommits(repo, headCommit, ranges, options.FirstParent)
+	if err != nil {
+		return nil, err
+	}
 
-	stopCollecting := false
+	commitsPerRange := make([][]*object.Commit, len(ranges))
+	changedFilesCache := make(map[plumbing.Hash][][]string)
 
-	err = commitIter.ForEach(func(commit *object.Commit) error {
-		if commit.Hash == commitHash {
-			stopCollecting = true // Stop when we reach the given commit
+	for index, commitRange := range ranges {
+		commits := commitsPerReleasedCommit[commitRange.Since]
+		if pathFilters[index] == nil {
+			commitsPerRange[index] = commits
 
-			return nil
+			continue
 		}
 
-		if !stopCollecting {
-			commits = append(commits, commit)
+		for _, commit := range commits {
+			changedFiles, found := changedFilesCache[commit.Hash]
+			if !found {
+				changedFiles, err = changedFilesPerParent(commit, options.FirstParent)
+				if err != nil {
+					return nil, err
+				}
+
+				changedFilesCache[commit.Hash] = changedFiles
+			}
+
+			if matchesAllParents(changedFiles, pathFilters[index]) {
+				commitsPerRange[index] = append(commitsPerRange[index], commit)
+			}
 		}
+	}
 
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to iterate commits: %w", err)
+	return commitsPerRange, nil
+}
+
+// walkReleasedCommits returns the commits reachable from the head commit that are not reachable from the
+// released commit, for each distinct released commit of the ranges.
+// The history is walked once for every maxReleasedCommits released commits.
+func walkReleasedCommits(
+	repo *git.Repository,
+	headCommit *object.Commit,
+	ranges []CommitRange,
+	firstParent bool,
+) (map[plumbing.Hash][]*object.Commit, error) {
+	releasedHashes := make([]plumbing.Hash, 0, len(ranges))
+
+	for _, commitRange := range ranges {
+		if !slices.Contains(releasedHashes, commitRange.Since) {
+			releasedHashes = append(releasedHashes, commitRange.Since)
+		}
 	}
 
-	if len(commits) == 0 {
-		return nil, ErrNoCommitsFound
+	commitsPerReleasedCommit := make(map[plumbing.Hash][]*object.Commit, len(releasedHashes))
+
+	for chunk := range slices.Chunk(releasedHashes, maxReleasedCommits) {
+		commits, err := newCommitWalker(repo, firstParent).walkMany(headCommit, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk commits: %w", err)
+		}
+
+		for index, hash := range chunk {
+			commitsPerReleasedCommit[hash] = commits[index]
+		}
 	}
 
-	return commits, nil
+	return commitsPerReleasedCommit, nil
 }
 
 // GetCommitMessagesSinceCommitHash returns the commit messages for all commits made after the specified commit hash.
@@ -206,8 +608,9 @@ func GetCommitsSinceCommitHash(
 func GetCommitMessagesSinceCommitHash(
 	repo *git.Repository,
 	commitHash plumbing.Hash,
+	options CommitOptions,
 ) ([]string, error) {
-	commits, err := GetCommitsSinceCommitHash(repo, commitHash)
+	commits, err := GetCommitsSinceCommitHash(repo, commitHash, options)
 	if err != nil {
 		// Error type could be ErrNoCommitsFound
 		return nil, fmt.Errorf("failed to get commits since commit hash: %w", err)
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/erNail/verscout/internal/semverutils"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -126,7 +128,7 @@ func TestGetLatestVersionTag_NoTags(t *testing.T) {
 	repo, err := CreateTestRepo()
 	require.NoError(t, err)
 
-	tagInfo, err := GetLatestVersionTag(repo)
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
 	require.ErrorIs(t, err, ErrNoTags)
 	assert.Nil(t, tagInfo)
 }
@@ -147,7 +149,7 @@ func TestGetLatestVersionTag_NoValidSemVerTags(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0.0", commitHash, nil)
 	require.NoError(t, err)
 
-	tagInfo, err := GetLatestVersionTag(repo)
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
 	require.ErrorIs(t, err, ErrNoValidVersionTags)
 	assert.Nil(t, tagInfo)
 }
@@ -188,10 +190,309 @@ func TestGetLatestVersionTag_WithValidSemVerTags(t *testing.T) {
 	_, err = repo.CreateTag("1.0.1", commitHash, nil)
 	require.NoError(t, err)
 
-	tagInfo, err := GetLatestVersionTag(repo)
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
 	require.NoError(t, err)
 	assert.NotNil(t, tagInfo)
+	assert.Equal(t, "1.1.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_CommitTime_WithValidSemVerTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(
+		repo,
+		"First commit",
+		"README.md",
+		"Hello, World!",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", commitHash, nil)
+	require.NoError(t, err)
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello again, World!",
+		time.Now().Add(1*time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.1.0", commitHash, nil)
+	require.NoError(t, err)
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Third commit",
+		"README.md",
+		"Hello once more, World!",
+		time.Now().Add(2*time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.1", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{Strategy: CommitTime})
+	require.NoError(t, err)
+	assert.NotNil(t, tagInfo)
+	assert.Equal(t, "1.0.1", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_SemVerPrecedence(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", commitHash, nil)
+	require.NoError(t, err)
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello again, World!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.1.0", commitHash, nil)
+	require.NoError(t, err)
+	// Tag on an older line, created on the newest commit
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Third commit",
+		"README.md",
+		"Hello once more!",
+		time.Now().Add(2*time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.1", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{Strategy: SemVerPrecedence})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_SemVerPrecedence_DefaultStrategy(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("2.0.0", commitHash, nil)
+	require.NoError(t, err)
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello again, World!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.9.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_SemVerPrecedence_PreReleaseBelowRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0-rc.10", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0-rc.9", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0-rc.10", tagInfo.Name)
+
+	_, err = repo.CreateTag("1.0.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err = GetLatestVersionTag(repo, LatestVersionTagOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_SemVerPrecedence_TieBrokenByCommitTime(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commitHash, nil)
+	require.NoError(t, err)
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello again, World!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0+build.2", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{Strategy: SemVerPrecedence})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0+build.2", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_SemVerPrecedence_TieBrokenByTagName(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{Strategy: SemVerPrecedence})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_CommitTime_TieBrokenBySemVerPrecedence(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.2.0", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.10.0", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.9.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{Strategy: CommitTime})
+	require.NoError(t, err)
+	assert.Equal(t, "1.10.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_CommitTime_TieBrokenByTagName(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.2.0", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.2.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{Strategy: CommitTime})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_ReachableFrom_IgnoresTagsOnOtherBranches(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	baseHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", baseHash, nil)
+	require.NoError(t, err)
+
+	// A newer release line on an unmerged branch
+	require.NoError(t, CheckoutNewBranch(repo, "release-2", baseHash))
+	commitHash, err := CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello again, World!",
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("2.0.0", commitHash, nil)
+	require.NoError(t, err)
+
+	// The maintenance line
+	require.NoError(t, CheckoutBranch(repo, "master"))
+	commitHash, err = CreateTestCommit(repo, "Third commit", "README.md", "Maintenance", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.1", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", tagInfo.Name)
+
+	tagInfo, err = GetLatestVersionTag(repo, LatestVersionTagOptions{ReachableFrom: "HEAD"})
+	require.NoError(t, err)
 	assert.Equal(t, "1.0.1", tagInfo.Name)
+
+	tagInfo, err = GetLatestVersionTag(repo, LatestVersionTagOptions{ReachableFrom: "release-2"})
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_ReachableFrom_NoReachableTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	baseHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutNewBranch(repo, "feature", baseHash))
+	commitHash, err := CreateTestCommit(repo, "Second commit", "README.md", "Hello again!", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", commitHash, nil)
+	require.NoError(t, err)
+	require.NoError(t, CheckoutBranch(repo, "master"))
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{ReachableFrom: "HEAD"})
+	require.ErrorIs(t, err, ErrNoValidVersionTags)
+	assert.Nil(t, tagInfo)
+}
+
+func TestGetLatestVersionTag_ReachableFrom_InvalidRevision(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{ReachableFrom: "does-not-exist"})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNoValidVersionTags)
+	assert.Nil(t, tagInfo)
+}
+
+func TestGetLatestVersionTag_InvalidStrategy(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{Strategy: "newest"})
+	require.ErrorIs(t, err, ErrInvalidSelectionStrategy)
+	assert.Nil(t, tagInfo)
 }
 
 func TestGetLatestVersionTag_WithMixedTags(t *testing.T) {
@@ -230,12 +531,134 @@ func TestGetLatestVersionTag_WithMixedTags(t *testing.T) {
 	_, err = repo.CreateTag("1.0.1", commitHash, nil)
 	require.NoError(t, err)
 
-	tagInfo, err := GetLatestVersionTag(repo)
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
 	require.NoError(t, err)
 	assert.NotNil(t, tagInfo)
 	assert.Equal(t, "1.0.1", tagInfo.Name)
 }
 
+func TestGetLatestVersionTag_WithPreReleaseTag(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(
+		repo,
+		"First commit",
+		"README.md",
+		"Hello, World!",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.3.0", commitHash, nil)
+	require.NoError(t, err)
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello again, World!",
+		time.Now().Add(1*time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.4.0-rc.1", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{})
+	require.NoError(t, err)
+	assert.NotNil(t, tagInfo)
+	assert.Equal(t, "v1.4.0-rc.1", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_ExcludePreReleases_SkipsPreReleaseTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(
+		repo,
+		"First commit",
+		"README.md",
+		"Hello, World!",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.3.0", commitHash, nil)
+	require.NoError(t, err)
+	commitHash, err = CreateTestCommit(
+		repo,
+		"Second commit",
+		"README.md",
+		"Hello again, World!",
+		time.Now().Add(1*time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.4.0-rc.1", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{ExcludePreReleases: true})
+	require.NoError(t, err)
+	assert.NotNil(t, tagInfo)
+	assert.Equal(t, "v1.3.0", tagInfo.Name)
+}
+
+func TestGetLatestVersionTag_ExcludePreReleases_OnlyPreReleaseTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(
+		repo,
+		"First commit",
+		"README.md",
+		"Hello, World!",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0-rc.1", commitHash, nil)
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{ExcludePreReleases: true})
+	require.ErrorIs(t, err, ErrNoValidVersionTags)
+	assert.Nil(t, tagInfo)
+}
+
+func TestGetVersions(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(
+		repo,
+		"First commit",
+		"README.md",
+		"Hello, World!",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.1.0-rc.1", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("not-a-version", commitHash, nil)
+	require.NoError(t, err)
+
+	versions, err := GetVersions(repo, nil)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.ElementsMatch(t, []string{"1.0.0", "1.1.0-rc.1"}, []string{versions[0].String(), versions[1].String()})
+}
+
+func TestGetVersions_NoTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+
+	versions, err := GetVersions(repo, nil)
+	require.ErrorIs(t, err, ErrNoTags)
+	assert.Empty(t, versions)
+}
+
 func TestGetLatestVersion_Success(t *testing.T) {
 	t.Parallel()
 
@@ -252,7 +675,7 @@ func TestGetLatestVersion_Success(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0", commitHash, nil)
 	require.NoError(t, err)
 
-	version, err := GetLatestVersion(repo)
+	version, err := GetLatestVersion(repo, LatestVersionTagOptions{})
 	require.NoError(t, err)
 	assert.NotNil(t, version)
 	assert.Equal(t, 1, version.Major)
@@ -266,7 +689,7 @@ func TestGetLatestVersion_NoTags(t *testing.T) {
 	repo, err := CreateTestRepo()
 	require.NoError(t, err)
 
-	version, err := GetLatestVersion(repo)
+	version, err := GetLatestVersion(repo, LatestVersionTagOptions{})
 	require.ErrorIs(t, err, ErrNoTags)
 	assert.Nil(t, version)
 }
@@ -287,7 +710,7 @@ func TestGetLatestVersion_NoValidVersionTags(t *testing.T) {
 	_, err = repo.CreateTag("not-a-version", commitHash, nil)
 	require.NoError(t, err)
 
-	version, err := GetLatestVersion(repo)
+	version, err := GetLatestVersion(repo, LatestVersionTagOptions{})
 	require.ErrorIs(t, err, ErrNoValidVersionTags)
 	assert.Nil(t, version)
 }
@@ -327,13 +750,122 @@ func TestGetCommitsSinceCommitHash_Success(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	commits, err := GetCommitsSinceCommitHash(repo, commitHash1)
+	commits, err := GetCommitsSinceCommitHash(repo, commitHash1, CommitOptions{})
 	require.NoError(t, err)
 	assert.Len(t, commits, 2)
 	assert.Equal(t, commitHash3, commits[0].Hash)
 	assert.Equal(t, commitHash2, commits[1].Hash)
 }
 
+func TestGetCommitsSinceCommitHash_IncludesOlderCommitsMergedAfterTag(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	baseHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutNewBranch(repo, "feature", baseHash))
+	featureHash, err := CreateTestCommit(repo, "feat: Feature", "feature.txt", "Feature", baseTime.Add(time.Hour))
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutBranch(repo, "master"))
+	tagHash, err := CreateTestCommit(repo, "fix: Released fix", "README.md", "Fixed", baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", tagHash, nil)
+	require.NoError(t, err)
+
+	mergeHash, err := CreateTestMergeCommit(repo, "Merge feature", featureHash, baseTime.Add(3*time.Hour))
+	require.NoError(t, err)
+
+	commits, err := GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, mergeHash, commits[0].Hash)
+	assert.Equal(t, featureHash, commits[1].Hash)
+}
+
+func TestGetCommitsSinceCommitHash_ExcludesNewerCommitsAlreadyReleased(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	_, err = CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+	// Committed with a clock ahead of the tagged commit
+	releasedHash, err := CreateTestCommit(repo, "fix: Released fix", "README.md", "Fixed", baseTime.Add(3*time.Hour))
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutNewBranch(repo, "release", releasedHash))
+	tagHash, err := CreateTestCommit(repo, "chore: Release", "CHANGELOG.md", "1.0.0", baseTime.Add(time.Hour))
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", tagHash, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutBranch(repo, "master"))
+	unreleasedHash, err := CreateTestCommit(repo, "feat: Unreleased", "feature.txt", "New", baseTime.Add(4*time.Hour))
+	require.NoError(t, err)
+	mergeHash, err := CreateTestMergeCommit(repo, "Merge release", tagHash, baseTime.Add(5*time.Hour))
+	require.NoError(t, err)
+
+	commits, err := GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, mergeHash, commits[0].Hash)
+	assert.Equal(t, unreleasedHash, commits[1].Hash)
+}
+
+func TestGetCommitsSinceCommitHash_FirstParent(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	tagHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", tagHash, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutNewBranch(repo, "feature", tagHash))
+	_, err = CreateTestCommit(repo, "feat: WIP", "feature.txt", "WIP", baseTime.Add(time.Hour))
+	require.NoError(t, err)
+	featureHash, err := CreateTestCommit(repo, "fix: typo", "feature.txt", "Done", baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutBranch(repo, "master"))
+	mainlineHash, err := CreateTestCommit(repo, "docs: Mainline", "README.md", "Docs", baseTime.Add(3*time.Hour))
+	require.NoError(t, err)
+	mergeHash, err := CreateTestMergeCommit(repo, "fix: Pull request", featureHash, baseTime.Add(4*time.Hour))
+	require.NoError(t, err)
+
+	commits, err := GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{FirstParent: true})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, mergeHash, commits[0].Hash)
+	assert.Equal(t, mainlineHash, commits[1].Hash)
+
+	commits, err = GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{})
+	require.NoError(t, err)
+	assert.Len(t, commits, 4)
+}
+
+func TestGetCommitsSinceCommitHash_FirstParent_NoCommits(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+
+	_, err = GetCommitsSinceCommitHash(repo, commitHash, CommitOptions{FirstParent: true})
+	require.ErrorIs(t, err, ErrNoCommitsFound)
+}
+
 func TestGetCommitsSinceCommitHash_NoCommits(t *testing.T) {
 	t.Parallel()
 
@@ -350,7 +882,7 @@ func TestGetCommitsSinceCommitHash_NoCommits(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0", commitHash1, nil)
 	require.NoError(t, err)
 
-	_, err = GetCommitsSinceCommitHash(repo, commitHash1)
+	_, err = GetCommitsSinceCommitHash(repo, commitHash1, CommitOptions{})
 	require.ErrorIs(t, err, ErrNoCommitsFound)
 }
 
@@ -389,7 +921,7 @@ func TestGetCommitMessagesSinceCommitHash_Success(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	messages, err := GetCommitMessagesSinceCommitHash(repo, commitHash1)
+	messages, err := GetCommitMessagesSinceCommitHash(repo, commitHash1, CommitOptions{})
 	require.NoError(t, err)
 	assert.Len(t, messages, 2)
 	assert.Equal(t, "Third commit", messages[0])
@@ -413,7 +945,7 @@ func TestGetCommitMessagesSinceCommitHash_NoCommits(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0", commitHash1, nil)
 	require.NoError(t, err)
 
-	_, err = GetCommitMessagesSinceCommitHash(repo, commitHash1)
+	_, err = GetCommitMessagesSinceCommitHash(repo, commitHash1, CommitOptions{})
 	require.ErrorIs(t, err, ErrNoCommitsFound)
 }
 
@@ -458,3 +990,152 @@ func TestGetCommitFromTag_AnnotatedTag(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, commitHash, commit.Hash)
 }
+
+func TestGetLatestVersionTag_TagFormat(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v3.0.0", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("api/v1.2.0", commitHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("api/v1.10.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagFormat, err := semverutils.NewTagFormat("api/v{version}", "")
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{TagFormat: tagFormat})
+	require.NoError(t, err)
+	assert.Equal(t, "api/v1.10.0", tagInfo.Name)
+	assert.Equal(t, "1.10.0", tagInfo.Version.String())
+
+	versions, err := GetVersions(repo, tagFormat)
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+}
+
+func TestGetLatestVersionTag_TagFormat_NoMatchingTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	commitHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commitHash, nil)
+	require.NoError(t, err)
+
+	tagFormat, err := semverutils.NewTagFormat("release-{version}", "")
+	require.NoError(t, err)
+
+	tagInfo, err := GetLatestVersionTag(repo, LatestVersionTagOptions{TagFormat: tagFormat})
+	require.ErrorIs(t, err, ErrNoValidVersionTags)
+	assert.Nil(t, tagInfo)
+}
+
+func TestGetLatestVersionTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	firstHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("billing/v1.0.0", firstHash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("shipping/v2.0.0", firstHash, nil)
+	require.NoError(t, err)
+	secondHash, err := CreateTestCommit(repo, "Second commit", "README.md", "Hello again!", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("billing/v1.1.0", secondHash, nil)
+	require.NoError(t, err)
+
+	// Tags on other branches are not reachable
+	require.NoError(t, CheckoutNewBranch(repo, "feature", firstHash))
+	featureHash, err := CreateTestCommit(repo, "Feature commit", "feature.txt", "WIP", time.Now())
+	require.NoError(t, err)
+	_, err = repo.CreateTag("shipping/v3.0.0", featureHash, nil)
+	require.NoError(t, err)
+	require.NoError(t, CheckoutBranch(repo, "master"))
+
+	tagFormats := make([]*semverutils.TagFormat, 0, 3)
+
+	for _, template := range []string{"billing/v{version}", "shipping/v{version}", "inventory/v{version}"} {
+		tagFormat, err := semverutils.NewTagFormat(template, "")
+		require.NoError(t, err)
+
+		tagFormats = append(tagFormats, tagFormat)
+	}
+
+	latestTags, err := GetLatestVersionTags(repo, tagFormats, LatestVersionTagOptions{ReachableFrom: "HEAD"})
+	require.NoError(t, err)
+	require.Len(t, latestTags, 3)
+	assert.Equal(t, "billing/v1.1.0", latestTags[0].Name)
+	assert.Equal(t, "shipping/v2.0.0", latestTags[1].Name)
+	assert.Nil(t, latestTags[2])
+}
+
+func TestGetLatestVersionTags_NoTags(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+
+	latestTags, err := GetLatestVersionTags(repo, []*semverutils.TagFormat{nil}, LatestVersionTagOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []*TagInfo{nil}, latestTags)
+}
+
+func TestGetLatestVersionTags_InvalidStrategy(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+
+	latestTags, err := GetLatestVersionTags(repo, nil, LatestVersionTagOptions{Strategy: "newest"})
+	require.ErrorIs(t, err, ErrInvalidSelectionStrategy)
+	assert.Nil(t, latestTags)
+}
+
+func TestGetCommitsForRanges(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	firstHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+	billingHash, err := CreateTestCommit(repo, "fix: billing", "billing/main.go", "fix", baseTime.Add(time.Hour))
+	require.NoError(t, err)
+	shippingHash, err := CreateTestCommit(repo, "feat: shipping", "shipping/main.go", "feat", baseTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	commits, err := GetCommitsForRanges(repo, []CommitRange{
+		{Since: firstHash, Paths: []string{"billing"}},
+		{Since: billingHash, Paths: []string{"billing"}},
+		{Since: firstHash, Paths: []string{"shipping"}},
+		{Since: firstHash},
+	}, CommitOptions{})
+	require.NoError(t, err)
+	require.Len(t, commits, 4)
+	assert.Equal(t, []plumbing.Hash{billingHash}, commitHashes(commits[0]))
+	assert.Empty(t, commits[1])
+	assert.Equal(t, []plumbing.Hash{shippingHash}, commitHashes(commits[2]))
+	assert.Equal(t, []plumbing.Hash{shippingHash, billingHash}, commitHashes(commits[3]))
+}
+
+func TestGetCommitsForRanges_InvalidPathGlob(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	firstHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", time.Now())
+	require.NoError(t, err)
+
+	commits, err := GetCommitsForRanges(repo, []CommitRange{{Since: firstHash, Paths: []string{"["}}}, CommitOptions{})
+	require.ErrorIs(t, err, ErrInvalidPathGlob)
+	assert.Nil(t, commits)
+}
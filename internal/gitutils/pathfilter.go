@@ -0,0 +1,200 @@
+package gitutils
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// globStar is the glob segment that matches any number of directories.
+const globStar = "**"
+
+// ErrInvalidPathGlob indicates that a path glob cannot be parsed.
+var ErrInvalidPathGlob = errors.New("invalid path glob")
+
+// PathFilter matches file paths relative to the repository root against a set of globs.
+// Each glob segment is matched using path.Match, and the segment "**" matches any number of directories.
+// A glob also matches all files within the directories it matches, e.g. "services/billing" matches
+// "services/billing/main.go". A glob prefixed with "!" excludes the files it matches, e.g. "!services/billing/sub".
+type PathFilter struct {
+	globs    [][]string
+	excludes [][]string
+}
+
+// NewPathFilter creates a PathFilter from the given globs, e.g. "services/billing/**" or "libs/*/go.mod".
+// Returns ErrInvalidPathGlob if a glob is malformed.
+func NewPathFilter(globs []string) (*PathFilter, error) {
+	filter := &PathFilter{globs: make([][]string, 0, len(globs))}
+
+	for _, glob := range globs {
+		pattern, excluded := strings.CutPrefix(glob, "!")
+
+		segments := splitPath(pattern)
+		if len(segments) == 0 {
+			return nil, fmt.Errorf("%w: %q is empty", ErrInvalidPathGlob, glob)
+		}
+
+		for _, segment := range segments {
+			_, err := path.Match(segment, "")
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %w", ErrInvalidPathGlob, glob, err)
+			}
+		}
+
+		if excluded {
+			filter.excludes = append(filter.excludes, segments)
+		} else {
+			filter.globs = append(filter.globs, segments)
+		}
+	}
+
+	return filter, nil
+}
+
+// Match reports whether the file path matches any of the globs, and none of the excluding globs.
+func (filter *PathFilter) Match(filePath string) bool {
+	segments := splitPath(filePath)
+	if len(segments) == 0 {
+		return false
+	}
+
+	for _, exclude := range filter.excludes {
+		if matchSegments(exclude, segments) {
+			return false
+		}
+	}
+
+	for _, glob := range filter.globs {
+		if matchSegments(glob, segments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitPath splits a slash separated path into its segments, ignoring a leading "./" and empty segments.
+func splitPath(filePath string) []string {
+	segments := strings.Split(strings.TrimPrefix(filePath, "./"), "/")
+	nonEmpty := segments[:0]
+
+	for _, segment := range segments {
+		if segment != "" {
+			nonEmpty = append(nonEmpty, segment)
+		}
+	}
+
+	return nonEmpty
+}
+
+// matchSegments reports whether the glob matches the path, or one of its parent directories.
+func matchSegments(glob []string, segments []string) bool {
+	if len(glob) == 0 {
+		return true
+	}
+
+	if glob[0] == globStar {
+		for skipped := 0; skipped <= len(segments); skipped++ {
+			if matchSegments(glob[1:], segments[skipped:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	// The error can be ignored, since the globs are validated in NewPathFilter
+	matched, _ := path.Match(glob[0], segments[0])
+
+	return matched && matchSegments(glob[1:], segments[1:])
+}
+
+// changedFilesPerParent returns the files changed by the commit compared to each of its parents,
+// or only the first parent if firstParent is set. A commit without parents is compared to an empty tree.
+// Like `git log -- <path>`, a merge commit only touches a path if it changes it compared to all of its parents,
+// see matchesAllParents, since the changes of a merged branch are already contained in the commits of that branch.
+func changedFilesPerParent(commit *object.Commit, firstParent bool) ([][]string, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of commit %s: %w", commit.Hash, err)
+	}
+
+	if commit.NumParents() == 0 {
+		changedFiles, err := changedFilesBetween(nil, tree)
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]string{changedFiles}, nil
+	}
+
+	parentCount := commit.NumParents()
+	if firstParent {
+		parentCount = 1
+	}
+
+	changedFilesPerParent := make([][]string, 0, parentCount)
+
+	for index := range parentCount {
+		parent, err := commit.Parent(index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent of commit %s: %w", commit.Hash, err)
+		}
+
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree of commit %s: %w", parent.Hash, err)
+		}
+
+		changedFiles, err := changedFilesBetween(parentTree, tree)
+		if err != nil {
+			return nil, err
+		}
+
+		changedFilesPerParent = append(changedFilesPerParent, changedFiles)
+	}
+
+	return changedFilesPerParent, nil
+}
+
+// changedFilesBetween returns the paths of the files changed between the two trees.
+// A nil tree is treated as an empty tree.
+func changedFilesBetween(from *object.Tree, to *object.Tree) ([]string, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff trees: %w", err)
+	}
+
+	changedFiles := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		if change.From.Name != "" {
+			changedFiles = append(changedFiles, change.From.Name)
+		}
+
+		if change.To.Name != "" && change.To.Name != change.From.Name {
+			changedFiles = append(changedFiles, change.To.Name)
+		}
+	}
+
+	return changedFiles, nil
+}
+
+// matchesAllParents reports whether the changes compared to each parent contain a file matching the filter.
+func matchesAllParents(changedFilesPerParent [][]string, filter *PathFilter) bool {
+	for _, changedFiles := range changedFilesPerParent {
+		if !slices.ContainsFunc(changedFiles, filter.Match) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,210 @@
+package gitutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPathFilter_InvalidGlob(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPathFilter([]string{"services/[billing"})
+	require.ErrorIs(t, err, ErrInvalidPathGlob)
+	assert.Nil(t, filter)
+}
+
+func TestNewPathFilter_EmptyGlob(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPathFilter([]string{"./"})
+	require.ErrorIs(t, err, ErrInvalidPathGlob)
+	assert.Nil(t, filter)
+}
+
+func TestPathFilterMatch_Directory(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPathFilter([]string{"./services/billing/"})
+	require.NoError(t, err)
+
+	assert.True(t, filter.Match("services/billing/main.go"))
+	assert.True(t, filter.Match("services/billing/internal/api.go"))
+	assert.False(t, filter.Match("services/billing-v2/main.go"))
+	assert.False(t, filter.Match("services/main.go"))
+}
+
+func TestPathFilterMatch_Wildcards(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPathFilter([]string{"libs/*/go.mod", "docs/*.md"})
+	require.NoError(t, err)
+
+	assert.True(t, filter.Match("libs/shared/go.mod"))
+	assert.False(t, filter.Match("libs/shared/go.sum"))
+	assert.False(t, filter.Match("libs/shared/nested/go.mod"))
+	assert.True(t, filter.Match("docs/README.md"))
+	assert.False(t, filter.Match("docs/images/logo.png"))
+}
+
+func TestPathFilterMatch_GlobStar(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPathFilter([]string{"**/*.proto", "services/**/handler.go"})
+	require.NoError(t, err)
+
+	assert.True(t, filter.Match("api.proto"))
+	assert.True(t, filter.Match("api/billing/v1/api.proto"))
+	assert.True(t, filter.Match("services/handler.go"))
+	assert.True(t, filter.Match("services/billing/http/handler.go"))
+	assert.False(t, filter.Match("services/billing/http/server.go"))
+	assert.False(t, filter.Match(""))
+}
+
+func TestPathFilterMatch_Exclude(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPathFilter([]string{"libs/auth", "!libs/auth/sub", "!**/*_test.go"})
+	require.NoError(t, err)
+
+	assert.True(t, filter.Match("libs/auth/auth.go"))
+	assert.True(t, filter.Match("libs/auth/subject/subject.go"))
+	assert.False(t, filter.Match("libs/auth/sub/go.mod"))
+	assert.False(t, filter.Match("libs/auth/auth_test.go"))
+}
+
+func TestNewPathFilter_EmptyExclude(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewPathFilter([]string{"libs/auth", "!"})
+	require.ErrorIs(t, err, ErrInvalidPathGlob)
+	assert.Nil(t, filter)
+}
+
+func TestGetCommitsSinceCommitHash_Paths(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	tagHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+	billingHash, err := CreateTestCommit(
+		repo,
+		"feat: billing",
+		"services/billing/main.go",
+		"billing",
+		baseTime.Add(time.Hour),
+	)
+	require.NoError(t, err)
+	_, err = CreateTestCommit(
+		repo,
+		"feat: shipping",
+		"services/shipping/main.go",
+		"shipping",
+		baseTime.Add(2*time.Hour),
+	)
+	require.NoError(t, err)
+
+	commits, err := GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{Paths: []string{"services/billing/**"}})
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, billingHash, commits[0].Hash)
+
+	commits, err = GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{Paths: []string{"services/inventory"}})
+	require.ErrorIs(t, err, ErrNoCommitsFound)
+	assert.Nil(t, commits)
+
+	commits, err = GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{Paths: []string{"services/[billing"}})
+	require.ErrorIs(t, err, ErrInvalidPathGlob)
+	assert.Nil(t, commits)
+}
+
+func TestChangedFilesPerParent_RootCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	rootHash, err := CreateTestCommit(repo, "feat: billing", "services/billing/main.go", "billing", time.Now())
+	require.NoError(t, err)
+
+	changedFiles, err := changedFilesPerParent(mustCommit(t, repo, rootHash), false)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"services/billing/main.go"}}, changedFiles)
+
+	assert.True(t, matchesAllParents(changedFiles, mustPathFilter(t, "services/billing")))
+	assert.False(t, matchesAllParents(changedFiles, mustPathFilter(t, "services/shipping")))
+}
+
+func TestGetCommitsSinceCommitHash_Paths_MergeCommits(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Now()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	tagHash, err := CreateTestCommit(repo, "First commit", "README.md", "Hello, World!", baseTime)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutNewBranch(repo, "feature", tagHash))
+	featureHash, err := CreateTestCommit(
+		repo,
+		"fix: billing",
+		"services/billing/main.go",
+		"billing",
+		baseTime.Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckoutBranch(repo, "master"))
+	_, err = CreateTestCommit(
+		repo,
+		"fix: shipping",
+		"services/shipping/main.go",
+		"shipping",
+		baseTime.Add(2*time.Hour),
+	)
+	require.NoError(t, err)
+	require.NoError(t, StageTestFile(repo, "services/billing/main.go", "billing"))
+	mergeHash, err := CreateTestMergeCommit(repo, "feat: Pull request", featureHash, baseTime.Add(3*time.Hour))
+	require.NoError(t, err)
+
+	paths := []string{"services/billing"}
+
+	// The merge commit only brings in the changes of the feature branch, so only the feature commit is collected
+	commits, err := GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{Paths: paths})
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, featureHash, commits[0].Hash)
+
+	// Compared to the first parent, the merge commit changes the billing service
+	commits, err = GetCommitsSinceCommitHash(repo, tagHash, CommitOptions{FirstParent: true, Paths: paths})
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, mergeHash, commits[0].Hash)
+}
+
+func mustCommit(t *testing.T, repo *git.Repository, hash plumbing.Hash) *object.Commit {
+	t.Helper()
+
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+
+	return commit
+}
+
+func mustPathFilter(t *testing.T, globs ...string) *PathFilter {
+	t.Helper()
+
+	filter, err := NewPathFilter(globs)
+	require.NoError(t, err)
+
+	return filter
+}
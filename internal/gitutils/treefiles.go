@@ -0,0 +1,71 @@
+package gitutils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// TreeFiles gives read access to the files of a commit, without checking it out.
+type TreeFiles struct {
+	tree *object.Tree
+}
+
+// GetTreeFiles returns the files of the commit the revision resolves to, e.g. "HEAD" or "main".
+func GetTreeFiles(repo *git.Repository, revision string) (*TreeFiles, error) {
+	commit, err := resolveCommit(repo, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of commit %s: %w", commit.Hash, err)
+	}
+
+	return &TreeFiles{tree: tree}, nil
+}
+
+// Paths returns the paths of all regular files, relative to the repository root, in lexical order.
+// Submodules are skipped.
+func (files *TreeFiles) Paths() ([]string, error) {
+	walker := object.NewTreeWalker(files.tree, true, nil)
+	defer walker.Close()
+
+	var paths []string
+
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			return paths, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk tree: %w", err)
+		}
+
+		if entry.Mode.IsFile() {
+			paths = append(paths, name)
+		}
+	}
+}
+
+// ReadFile returns the content of the file at the path relative to the repository root.
+// Returns object.ErrFileNotFound if the file does not exist.
+func (files *TreeFiles) ReadFile(filePath string) ([]byte, error) {
+	file, err := files.tree.File(filePath)
+	if err != nil {
+		// Error type could be object.ErrFileNotFound
+		return nil, fmt.Errorf("failed to find file %s: %w", filePath, err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return []byte(content), nil
+}
@@ -0,0 +1,66 @@
+package gitutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTreeFiles(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	_, err = CreateTestCommit(repo, "Initial commit", "go.mod", "module example.com/acme", time.Now())
+	require.NoError(t, err)
+	_, err = CreateTestCommit(repo, "feat: auth", "libs/auth/go.mod", "module example.com/acme/libs/auth", time.Now())
+	require.NoError(t, err)
+
+	files, err := GetTreeFiles(repo, "HEAD")
+	require.NoError(t, err)
+
+	paths, err := files.Paths()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go.mod", "libs/auth/go.mod"}, paths)
+
+	content, err := files.ReadFile("libs/auth/go.mod")
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/acme/libs/auth", string(content))
+}
+
+func TestGetTreeFiles_Ref(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	firstCommitHash, err := CreateTestCommit(repo, "Initial commit", "go.mod", "module example.com/acme", time.Now())
+	require.NoError(t, err)
+	_, err = CreateTestCommit(repo, "feat: auth", "libs/auth/go.mod", "module example.com/acme/libs/auth", time.Now())
+	require.NoError(t, err)
+
+	files, err := GetTreeFiles(repo, firstCommitHash.String())
+	require.NoError(t, err)
+
+	paths, err := files.Paths()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go.mod"}, paths)
+
+	_, err = files.ReadFile("libs/auth/go.mod")
+	require.ErrorIs(t, err, object.ErrFileNotFound)
+}
+
+func TestGetTreeFiles_InvalidRef(t *testing.T) {
+	t.Parallel()
+
+	repo, err := CreateTestRepo()
+	require.NoError(t, err)
+	_, err = CreateTestCommit(repo, "Initial commit", "go.mod", "module example.com/acme", time.Now())
+	require.NoError(t, err)
+
+	files, err := GetTreeFiles(repo, "missing")
+	require.Error(t, err)
+	assert.Nil(t, files)
+}
@@ -1,24 +1,45 @@
 package semverutils
 
-import (
-	"fmt"
-	"os"
-	"path/filepath"
-
-	log "github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v3"
-)
-
-// BumpPatterns holds regex patterns for each bump type.
+// BumpPatterns holds regex patterns for each bump type, and the bump types of conventional commit types.
 type BumpPatterns struct {
 	MajorPatterns []string `yaml:"majorPatterns"`
 	MinorPatterns []string `yaml:"minorPatterns"`
 	PatchPatterns []string `yaml:"patchPatterns"`
+	// Types maps conventional commit types, optionally with a scope, to bump types,
+	// e.g. "feat" to MinorBump and "feat(internal)" to PatchBump. They take precedence over the patterns.
+	// See TypeBumpType.
+	Types map[string]BumpType `yaml:"types,omitempty"`
+	// Breaking is the bump type of commits marked as breaking changes by Conventional Commits, either with a "!"
+	// after the type or with a BREAKING CHANGE footer. It takes precedence over the types and the patterns.
+	// NoBump disables the rule, so breaking changes are only bumped by the types and the patterns.
+	Breaking BumpType `yaml:"breaking,omitempty"`
 }
 
 // BumpConfig holds the configuration for version bumping.
 type BumpConfig struct {
-	Bumps BumpPatterns `yaml:"bumps"`
+	// Preset is the name of the preset the bumps are based on, if any. See PresetBumps.
+	Preset string       `yaml:"preset,omitempty"`
+	Bumps  BumpPatterns `yaml:"bumps"`
+	// InitialDevelopment lowers the bumps while the major version is 0. See EffectiveBumpType.
+	InitialDevelopment bool `yaml:"initialDevelopment,omitempty"`
+	// TagFormat is a template for the version tags, containing the placeholder "{version}", e.g. "release-{version}".
+	TagFormat string `yaml:"tagFormat,omitempty"`
+	// TagPattern is a regex for the version tags, with a named group "version", e.g. `^api/v(?P<version>.+)$`.
+	TagPattern string `yaml:"tagPattern,omitempty"`
+	// Components are the independently versioned parts of a monorepo. See Component.
+	Components []Component `yaml:"components,omitempty"`
+	// Groups are sets of components that are versioned in lockstep. See Group.
+	Groups []Group `yaml:"groups,omitempty"`
+	// Discover are the ecosystems whose workspace manifests are used to discover further components,
+	// e.g. "go", "npm" or "cargo".
+	Discover []string `yaml:"discover,omitempty"`
+}
+
+// CompileTagFormat returns the TagFormat described by the config.
+// If neither TagFormat nor TagPattern are set, DefaultTagFormat is returned.
+// Returns ErrInvalidTagFormat if the tag format cannot be used.
+func (bumpConfig BumpConfig) CompileTagFormat() (*TagFormat, error) {
+	return NewTagFormat(bumpConfig.TagFormat, bumpConfig.TagPattern)
 }
 
 // DefaultBumpConfig provides the verscout default bump patterns.
@@ -34,28 +55,6 @@ var DefaultBumpConfig = BumpConfig{
 		PatchPatterns: []string{
 			`^fix(\(.*\))?:`,
 		},
+		Breaking: MajorBump,
 	},
 }
-
-// LoadBumpConfigFromFile loads a BumpConfig from a YAML file.
-func LoadBumpConfigFromFile(configFilePath string) (BumpConfig, error) {
-	log.WithField("configFile", configFilePath).Info("Loading config file")
-
-	cleanedConfigFilePath := filepath.Clean(configFilePath)
-
-	file, err := os.Open(cleanedConfigFilePath)
-	if err != nil {
-		return BumpConfig{}, fmt.Errorf("failed to open config file: %w", err)
-	}
-
-	var config BumpConfig
-
-	decoder := yaml.NewDecoder(file)
-
-	err = decoder.Decode(&config)
-	if err != nil {
-		return BumpConfig{}, fmt.Errorf("failed to decode config file: %w", err)
-	}
-
-	return config, nil
-}
@@ -9,6 +9,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// compileBumpConfig compiles the config, failing the test if it is invalid.
+func compileBumpConfig(t *testing.T, bumpConfig BumpConfig) CompiledBumpConfig {
+	t.Helper()
+
+	compiled, err := bumpConfig.Compile()
+	require.NoError(t, err)
+
+	return compiled
+}
+
 func TestLoadBumpConfigFromFile_Success(t *testing.T) {
 	t.Parallel()
 
@@ -60,3 +70,151 @@ func TestLoadBumpConfigFromFile_InvalidYAML(t *testing.T) {
 	require.Error(t, err)
 	require.NotErrorIs(t, err, os.ErrNotExist)
 }
+
+func TestLoadBumpConfigFromFile_TagFormat(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+tagFormat: "release-{version}"
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	config, err := LoadBumpConfigFromFile(tmpFile)
+	require.NoError(t, err)
+	assert.Equal(t, "release-{version}", config.TagFormat)
+	assert.Equal(t, DefaultBumpConfig.Bumps, config.Bumps)
+
+	tagFormat, err := config.CompileTagFormat()
+	require.NoError(t, err)
+	assert.Equal(t, "release-2.0.0", tagFormat.Render("2.0.0", ""))
+}
+
+func TestLoadBumpConfigFromFile_Components(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - name: billing
+    tagPrefix: billing/v
+    paths:
+      - services/billing/**
+  - name: shipping
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	config, err := LoadBumpConfigFromFile(tmpFile)
+	require.NoError(t, err)
+	require.Len(t, config.Components, 2)
+	assert.Equal(t, Component{Name: "billing", TagPrefix: "billing/v", Paths: []string{"services/billing/**"}},
+		config.Components[0])
+	assert.Equal(t, Component{Name: "shipping"}, config.Components[1])
+}
+
+func TestLoadBumpConfigFromFile_DuplicateComponents(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - name: billing
+  - name: billing
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	_, err = LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidComponent)
+}
+
+func TestLoadBumpConfigFromFile_ComponentWithoutName(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - tagPrefix: billing/v
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	_, err = LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidComponent)
+}
+
+func TestLoadBumpConfigFromFile_UnknownDependency(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - name: billing
+    dependsOn:
+      - shared
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	_, err = LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidComponent)
+}
+
+func TestLoadBumpConfigFromFile_DependencyCycle(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - name: billing
+    dependsOn:
+      - shipping
+  - name: shipping
+    dependsOn:
+      - billing
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	_, err = LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidComponent)
+	assert.ErrorContains(t, err, "billing -> shipping -> billing")
+}
+
+func TestLoadBumpConfigFromFile_DiscoverDefersComponentValidation(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+discover:
+  - go
+components:
+  - name: app
+    dependsOn:
+      - libs/auth
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	config, err := LoadBumpConfigFromFile(tmpFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, config.Discover)
+}
+
+func TestLoadBumpConfigFromFile_InitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+initialDevelopment: true
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	config, err := LoadBumpConfigFromFile(tmpFile)
+	require.NoError(t, err)
+	assert.True(t, config.InitialDevelopment)
+	assert.Equal(t, DefaultBumpConfig.Bumps, config.Bumps)
+}
@@ -0,0 +1,154 @@
+package semverutils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ErrInvalidBumpPattern is returned when a bump pattern is no valid regex.
+var ErrInvalidBumpPattern = errors.New("invalid bump pattern")
+
+// CompiledBumpConfig is a validated BumpConfig with compiled bump patterns and tag format,
+// so they are compiled only once, and not for every commit. See BumpConfig.Compile.
+type CompiledBumpConfig struct {
+	BumpConfig
+
+	majorPatterns []*regexp.Regexp
+	minorPatterns []*regexp.Regexp
+	patchPatterns []*regexp.Regexp
+	tagFormat     *TagFormat
+	// sources maps the keys of the config file to the source of their value. See Source.
+	sources map[string]string
+}
+
+// fieldError is an error caused by the value of a field of the config, e.g. "bumps.majorPatterns.1".
+// The field is given as the path of keys and indexes to the value, so the position of the value in the
+// config file can be reported. See positionedError.
+type fieldError struct {
+	path []string
+	err  error
+}
+
+func (err *fieldError) Error() string {
+	return fmt.Sprintf("%s: %v", joinFieldPath(err.path), err.err)
+}
+
+func (err *fieldError) Unwrap() error {
+	return err.err
+}
+
+// Compile validates the config and compiles its bump patterns and tag format.
+// Returns ErrInvalidBumpPattern, ErrInvalidTypeRule, ErrInvalidTagFormat or ErrInvalidComponent
+// if the config is invalid. With Discover, the components and groups are validated once the discovered
+// components are added, since the components can refer to discovered ones. See CompiledBumpConfig.WithComponents.
+func (bumpConfig BumpConfig) Compile() (CompiledBumpConfig, error) {
+	compiled := CompiledBumpConfig{BumpConfig: bumpConfig}
+
+	var err error
+
+	compiled.majorPatterns, err = compilePatterns("majorPatterns", bumpConfig.Bumps.MajorPatterns)
+	if err != nil {
+		return CompiledBumpConfig{}, err
+	}
+
+	compiled.minorPatterns, err = compilePatterns("minorPatterns", bumpConfig.Bumps.MinorPatterns)
+	if err != nil {
+		return CompiledBumpConfig{}, err
+	}
+
+	compiled.patchPatterns, err = compilePatterns("patchPatterns", bumpConfig.Bumps.PatchPatterns)
+	if err != nil {
+		return CompiledBumpConfig{}, err
+	}
+
+	compiled.Bumps.Types, err = normalizeTypes(bumpConfig.Bumps.Types)
+	if err != nil {
+		return CompiledBumpConfig{}, err
+	}
+
+	compiled.tagFormat, err = bumpConfig.CompileTagFormat()
+	if err != nil {
+		field := "tagFormat"
+		if bumpConfig.TagFormat == "" {
+			field = "tagPattern"
+		}
+
+		return CompiledBumpConfig{}, &fieldError{path: []string{field}, err: err}
+	}
+
+	if len(bumpConfig.Discover) == 0 {
+		err = validateComponents(bumpConfig.Components)
+		if err != nil {
+			return CompiledBumpConfig{}, &fieldError{path: []string{"components"}, err: err}
+		}
+
+		err = validateGroups(bumpConfig.Groups, bumpConfig.Components)
+		if err != nil {
+			return CompiledBumpConfig{}, &fieldError{path: []string{"groups"}, err: err}
+		}
+	}
+
+	return compiled, nil
+}
+
+// CompiledTagFormat returns the TagFormat described by the config.
+func (config CompiledBumpConfig) CompiledTagFormat() *TagFormat {
+	return config.tagFormat
+}
+
+// WithComponents returns a copy of the config with the components added, see BumpConfig.WithComponents.
+func (config CompiledBumpConfig) WithComponents(components []Component) (CompiledBumpConfig, error) {
+	bumpConfig, err := config.BumpConfig.WithComponents(components)
+	if err != nil {
+		return CompiledBumpConfig{}, err
+	}
+
+	config.BumpConfig = bumpConfig
+
+	return config, nil
+}
+
+// patternBumpType returns the bump type of the first pattern matching the message, checking the major patterns
+// first and the patch patterns last.
+func (config CompiledBumpConfig) patternBumpType(message string) BumpType {
+	patternsPerBumpType := []struct {
+		bumpType BumpType
+		patterns []*regexp.Regexp
+	}{
+		{MajorBump, config.majorPatterns},
+		{MinorBump, config.minorPatterns},
+		{PatchBump, config.patchPatterns},
+	}
+
+	for _, patterns := range patternsPerBumpType {
+		for _, pattern := range patterns.patterns {
+			if pattern.MatchString(message) {
+				return patterns.bumpType
+			}
+		}
+	}
+
+	return NoBump
+}
+
+// compilePatterns compiles the patterns of the field of BumpPatterns.
+// Returns ErrInvalidBumpPattern if a pattern is no valid regex.
+func compilePatterns(field string, patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+
+	for index, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, &fieldError{
+				path: []string{"bumps", field, strconv.Itoa(index)},
+				err:  fmt.Errorf("%w: %w", ErrInvalidBumpPattern, err),
+			}
+		}
+
+		compiled = append(compiled, regex)
+	}
+
+	return compiled, nil
+}
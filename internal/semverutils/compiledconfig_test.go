@@ -0,0 +1,109 @@
+package semverutils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile_Default(t *testing.T) {
+	t.Parallel()
+
+	config, err := DefaultBumpConfig.Compile()
+	require.NoError(t, err)
+	assert.Same(t, DefaultTagFormat, config.CompiledTagFormat())
+	assert.Equal(t, MinorBump, DetermineBumpType([]string{"feat: feature"}, config))
+}
+
+func TestCompile_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	_, err := BumpConfig{Bumps: BumpPatterns{MinorPatterns: []string{`^feat(`}}}.Compile()
+	require.ErrorIs(t, err, ErrInvalidBumpPattern)
+	assert.ErrorContains(t, err, "bumps.minorPatterns[0]")
+}
+
+func TestCompile_InvalidTagPattern(t *testing.T) {
+	t.Parallel()
+
+	_, err := BumpConfig{TagPattern: `^v(.+)$`}.Compile()
+	require.ErrorIs(t, err, ErrInvalidTagFormat)
+	assert.ErrorContains(t, err, "tagPattern")
+}
+
+func TestLoadBumpConfigFromFile_EmptyFile(t *testing.T) {
+	t.Parallel()
+
+	config, err := LoadBumpConfigFromFile(writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), ""))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBumpConfig.Bumps, config.Bumps)
+}
+
+func TestLoadBumpConfigFromFile_InvalidPatternPosition(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), `
+bumps:
+  majorPatterns:
+    - "^BREAK:"
+    - "^break("
+`)
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrInvalidBumpPattern)
+	assert.ErrorContains(t, err, configPath+":5:7: bumps.majorPatterns[1]: invalid bump pattern")
+}
+
+func TestLoadBumpConfigFromFile_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), "bumps:\n  minorPattern:\n    - \"^feat:\"\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrUnknownConfigKey)
+	assert.ErrorContains(t, err, configPath+":2:3: unknown config key: minorPattern")
+}
+
+func TestLoadBumpConfigFromFile_UnknownKeyOfComponent(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "config.yaml"),
+		"components:\n  - name: billing\n    tag_prefix: billing-v\n",
+	)
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrUnknownConfigKey)
+	assert.ErrorContains(t, err, configPath+":3:5: unknown config key: tag_prefix")
+}
+
+func TestLoadBumpConfigFromFile_InvalidBumpTypePosition(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "config.yaml"),
+		"bumps:\n  types:\n    feat: minor\n    fix: small\n",
+	)
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrInvalidBumpType)
+	assert.ErrorContains(t, err, configPath+":4:10:")
+}
+
+func TestLoadBumpConfigFromFile_InvalidComponentPosition(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "config.yaml"),
+		"tagFormat: v{version}\ncomponents:\n  - name: billing\n  - name: billing\n",
+	)
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrInvalidComponent)
+	assert.ErrorContains(t, err, configPath+":3:3: components: invalid component")
+}
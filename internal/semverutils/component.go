@@ -0,0 +1,328 @@
+package semverutils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrUnknownComponent is returned when a component is not defined in the config.
+	ErrUnknownComponent = errors.New("unknown component")
+	// ErrInvalidComponent is returned when a component definition cannot be used.
+	ErrInvalidComponent = errors.New("invalid component")
+	// ErrNoComponents is returned when components are required, but the config does not define any.
+	ErrNoComponents = errors.New("no components defined")
+)
+
+// Component is an independently versioned part of a monorepo, e.g. a service or a library.
+type Component struct {
+	// Name identifies the component, e.g. "billing".
+	Name string `yaml:"name"`
+	// TagPrefix is the prefix of the component's version tags, e.g. "billing/v" for "billing/v1.2.3".
+	// Defaults to the name followed by "/v".
+	TagPrefix string `yaml:"tagPrefix,omitempty"`
+	// Paths are globs of the files belonging to the component, e.g. "services/billing/**".
+	// Only commits changing these files affect the component's version. If empty, all commits are taken into account.
+	Paths []string `yaml:"paths,omitempty"`
+	// DependsOn are the names of the components this component depends on, e.g. "shared-lib".
+	// If a dependency is bumped, the component gets at least a patch bump as well. See PropagateBumps.
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+}
+
+// BumpPropagation describes why a component is bumped because of other components.
+type BumpPropagation struct {
+	// Dependencies is the chain of dependencies from the bumped component to the component
+	// whose bump causes the propagation, e.g. ["shared-lib", "core"] if the component depends on "shared-lib",
+	// which depends on "core".
+	Dependencies []string
+	// Group is the name of the group whose highest bump the component gets. Empty if the bump is caused by
+	// Dependencies.
+	Group string
+}
+
+// Reason returns a human readable reason for the propagated bump.
+func (propagation BumpPropagation) Reason() string {
+	if propagation.Group != "" {
+		return fmt.Sprintf("versioned in lockstep with group %s", propagation.Group)
+	}
+
+	return fmt.Sprintf("depends on %s, which is bumped", strings.Join(propagation.Dependencies, " -> "))
+}
+
+// TagFormat returns the TagFormat of the component's version tags, consisting of the tag prefix and the version.
+func (component Component) TagFormat() (*TagFormat, error) {
+	tagPrefix := component.TagPrefix
+	if tagPrefix == "" {
+		tagPrefix = component.Name + "/v"
+	}
+
+	return NewTagFormat(tagPrefix+VersionPlaceholder, "")
+}
+
+// Component returns the component with the given name.
+// Returns ErrUnknownComponent if the config does not define the component.
+func (bumpConfig BumpConfig) Component(name string) (*Component, error) {
+	for index := range bumpConfig.Components {
+		if bumpConfig.Components[index].Name == name {
+			return &bumpConfig.Components[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownComponent, name)
+}
+
+// WithComponents returns a copy of the config with the components added,
+// except the ones with the same name as a component already defined in the config.
+// Returns ErrInvalidComponent if the resulting components or groups are invalid.
+func (bumpConfig BumpConfig) WithComponents(components []Component) (BumpConfig, error) {
+	allComponents := slices.Clone(bumpConfig.Components)
+
+	for _, component := range components {
+		_, err := bumpConfig.Component(component.Name)
+		if err == nil {
+			log.WithField("component", component.Name).Info("Using the component defined in the config")
+
+			continue
+		}
+
+		allComponents = append(allComponents, component)
+	}
+
+	err := validateComponents(allComponents)
+	if err != nil {
+		return BumpConfig{}, err
+	}
+
+	err = validateGroups(bumpConfig.Groups, allComponents)
+	if err != nil {
+		return BumpConfig{}, err
+	}
+
+	bumpConfig.Components = allComponents
+
+	return bumpConfig, nil
+}
+
+// RelatedComponents returns the component with the given name, followed by all components that can
+// affect its bump, in the order of the config. These are the components it depends on, the other members
+// of its group, and in turn their dependencies and groups.
+// Returns ErrUnknownComponent if the config does not define the component.
+func (bumpConfig BumpConfig) RelatedComponents(name string) ([]Component, error) {
+	component, err := bumpConfig.Component(name)
+	if err != nil {
+		return nil, err
+	}
+
+	required := make(map[string]bool)
+	pending := []string{name}
+
+	for len(pending) > 0 {
+		related := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if required[related] {
+			continue
+		}
+
+		required[related] = true
+
+		relatedComponent, err := bumpConfig.Component(related)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, relatedComponent.DependsOn...)
+
+		if group := bumpConfig.GroupOf(related); group != nil {
+			pending = append(pending, group.Components...)
+		}
+	}
+
+	components := []Component{*component}
+
+	for _, other := range bumpConfig.Components {
+		if other.Name != name && required[other.Name] {
+			components = append(components, other)
+		}
+	}
+
+	return components, nil
+}
+
+// PropagateBumps raises the bump types of the components caused by their own changes,
+// which are given by the bumps map of component names to bump types:
+// Every component that depends on a bumped component, directly or transitively, gets at least a patch bump,
+// and all members of a group get the highest bump of any member.
+// It returns the resulting bump types, and the propagations for the components whose bump type was raised.
+// All dependencies of the components must be part of the components. Group members that are not part of
+// the components are ignored.
+func PropagateBumps(
+	components []Component,
+	groups []Group,
+	bumps map[string]BumpType,
+) (map[string]BumpType, map[string]BumpPropagation) {
+	resultingBumps := make(map[string]BumpType, len(components))
+	propagations := make(map[string]BumpPropagation)
+
+	for _, component := range components {
+		resultingBumps[component.Name] = bumps[component.Name]
+	}
+
+	// Raising a bump can cause further bumps through dependencies and groups,
+	// so repeat until nothing changes. This ends, since every change raises a bump type.
+	for changed := true; changed; {
+		changed = propagateDependencyBumps(components, resultingBumps, propagations)
+
+		if propagateGroupBumps(groups, resultingBumps, propagations) {
+			changed = true
+		}
+	}
+
+	return resultingBumps, propagations
+}
+
+// propagateDependencyBumps gives every component without a bump that depends on a bumped component a patch bump.
+// Reports whether any bump type was raised.
+func propagateDependencyBumps(
+	components []Component,
+	bumps map[string]BumpType,
+	propagations map[string]BumpPropagation,
+) bool {
+	changed := false
+
+	for _, component := range components {
+		if bumps[component.Name] != NoBump {
+			continue
+		}
+
+		for _, dependency := range component.DependsOn {
+			if bumps[dependency] == NoBump {
+				continue
+			}
+
+			bumps[component.Name] = PatchBump
+			propagations[component.Name] = BumpPropagation{
+				Dependencies: append([]string{dependency}, propagations[dependency].Dependencies...),
+			}
+			changed = true
+
+			break
+		}
+	}
+
+	return changed
+}
+
+// propagateGroupBumps gives all members of each group the highest bump type of any member.
+// Reports whether any bump type was raised.
+func propagateGroupBumps(groups []Group, bumps map[string]BumpType, propagations map[string]BumpPropagation) bool {
+	changed := false
+
+	for _, group := range groups {
+		highestBump := NoBump
+
+		for _, member := range group.Components {
+			highestBump = max(highestBump, bumps[member])
+		}
+
+		for _, member := range group.Components {
+			bumpType, found := bumps[member]
+			if !found || bumpType >= highestBump {
+				continue
+			}
+
+			bumps[member] = highestBump
+			propagations[member] = BumpPropagation{Group: group.Name}
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// validateComponents checks that every component has a unique name,
+// and only depends on other existing components without any cycles.
+// Returns ErrInvalidComponent otherwise.
+func validateComponents(components []Component) error {
+	byName := make(map[string]Component, len(components))
+
+	for _, component := range components {
+		if component.Name == "" {
+			return fmt.Errorf("%w: every component needs a name", ErrInvalidComponent)
+		}
+
+		if _, found := byName[component.Name]; found {
+			return fmt.Errorf("%w: %s is defined more than once", ErrInvalidComponent, component.Name)
+		}
+
+		byName[component.Name] = component
+	}
+
+	for _, component := range components {
+		for _, dependency := range component.DependsOn {
+			if _, found := byName[dependency]; !found {
+				return fmt.Errorf(
+					"%w: %s depends on unknown component %s",
+					ErrInvalidComponent,
+					component.Name,
+					dependency,
+				)
+			}
+		}
+	}
+
+	return validateNoDependencyCycles(components, byName)
+}
+
+// validateNoDependencyCycles checks that no component depends on itself, directly or transitively.
+// Returns ErrInvalidComponent otherwise.
+func validateNoDependencyCycles(components []Component, byName map[string]Component) error {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	states := make(map[string]int, len(components))
+
+	var visit func(name string, path []string) error
+
+	visit = func(name string, path []string) error {
+		switch states[name] {
+		case inProgress:
+			return fmt.Errorf(
+				"%w: dependency cycle %s",
+				ErrInvalidComponent,
+				strings.Join(append(path, name), " -> "),
+			)
+		case done:
+			return nil
+		}
+
+		states[name] = inProgress
+
+		for _, dependency := range byName[name].DependsOn {
+			err := visit(dependency, append(path, name))
+			if err != nil {
+				return err
+			}
+		}
+
+		states[name] = done
+
+		return nil
+	}
+
+	for _, component := range components {
+		err := visit(component.Name, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
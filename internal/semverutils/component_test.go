@@ -0,0 +1,161 @@
+package semverutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpConfigComponent(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{Components: []Component{{Name: "billing"}, {Name: "shipping"}}}
+
+	component, err := config.Component("shipping")
+	require.NoError(t, err)
+	assert.Equal(t, "shipping", component.Name)
+}
+
+func TestBumpConfigComponent_Unknown(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{Components: []Component{{Name: "billing"}}}
+
+	component, err := config.Component("inventory")
+	require.ErrorIs(t, err, ErrUnknownComponent)
+	assert.Nil(t, component)
+}
+
+func TestComponentTagFormat(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := Component{Name: "billing", TagPrefix: "services/billing-"}.TagFormat()
+	require.NoError(t, err)
+
+	version, err := tagFormat.ExtractVersion("services/billing-1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", version.String())
+	assert.Equal(t, "services/billing-1.3.0", tagFormat.Render("1.3.0", "services/billing-1.2.3"))
+}
+
+func TestComponentTagFormat_DefaultTagPrefix(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := Component{Name: "billing"}.TagFormat()
+	require.NoError(t, err)
+
+	_, err = tagFormat.ExtractVersion("billing/v1.2.3")
+	require.NoError(t, err)
+	_, err = tagFormat.ExtractVersion("v1.2.3")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+}
+
+func TestBumpConfigRelatedComponents(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{Components: []Component{
+		{Name: "shared"},
+		{Name: "billing", DependsOn: []string{"shared"}},
+		{Name: "shipping"},
+		{Name: "storefront", DependsOn: []string{"billing"}},
+	}}
+
+	components, err := config.RelatedComponents("storefront")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+
+	assert.Equal(t, []string{"storefront", "shared", "billing"}, names)
+}
+
+func TestBumpConfigRelatedComponents_Unknown(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{Components: []Component{{Name: "billing"}}}
+
+	components, err := config.RelatedComponents("inventory")
+	require.ErrorIs(t, err, ErrUnknownComponent)
+	assert.Nil(t, components)
+}
+
+func TestPropagateBumps_Direct(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{{Name: "billing", DependsOn: []string{"shared"}}, {Name: "shared"}}
+
+	bumps, propagations := PropagateBumps(components, nil, map[string]BumpType{"shared": MajorBump})
+	assert.Equal(t, map[string]BumpType{"billing": PatchBump, "shared": MajorBump}, bumps)
+	assert.Equal(t, map[string]BumpPropagation{"billing": {Dependencies: []string{"shared"}}}, propagations)
+	assert.Equal(t, "depends on shared, which is bumped", propagations["billing"].Reason())
+}
+
+func TestPropagateBumps_Transitive(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{Name: "storefront", DependsOn: []string{"billing"}},
+		{Name: "billing", DependsOn: []string{"shared"}},
+		{Name: "shared"},
+	}
+
+	bumps, propagations := PropagateBumps(components, nil, map[string]BumpType{"shared": PatchBump})
+	assert.Equal(t, PatchBump, bumps["storefront"])
+	assert.Equal(t, "depends on billing -> shared, which is bumped", propagations["storefront"].Reason())
+}
+
+func TestPropagateBumps_KeepsOwnBump(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{{Name: "billing", DependsOn: []string{"shared"}}, {Name: "shared"}}
+
+	bumps, propagations := PropagateBumps(
+		components,
+		nil,
+		map[string]BumpType{"billing": MinorBump, "shared": PatchBump},
+	)
+	assert.Equal(t, MinorBump, bumps["billing"])
+	assert.Empty(t, propagations)
+}
+
+func TestPropagateBumps_UnchangedDependency(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{{Name: "billing", DependsOn: []string{"shared"}}, {Name: "shared"}}
+
+	bumps, propagations := PropagateBumps(components, nil, map[string]BumpType{})
+	assert.Equal(t, map[string]BumpType{"billing": NoBump, "shared": NoBump}, bumps)
+	assert.Empty(t, propagations)
+}
+
+func TestBumpConfigWithComponents(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{
+		Components: []Component{{Name: "app", TagPrefix: "app-", DependsOn: []string{"libs/auth"}}},
+		Groups:     []Group{{Name: "all", Components: []string{"app", "libs/auth"}}},
+	}
+
+	configWithComponents, err := config.WithComponents([]Component{
+		{Name: "libs/auth", TagPrefix: "libs/auth/v"},
+		{Name: "app", TagPrefix: "app/v"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Component{
+		{Name: "app", TagPrefix: "app-", DependsOn: []string{"libs/auth"}},
+		{Name: "libs/auth", TagPrefix: "libs/auth/v"},
+	}, configWithComponents.Components)
+	assert.Len(t, config.Components, 1)
+}
+
+func TestBumpConfigWithComponents_UnknownDependency(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{Components: []Component{{Name: "app", DependsOn: []string{"libs/db"}}}}
+
+	_, err := config.WithComponents([]Component{{Name: "libs/auth"}})
+	require.ErrorIs(t, err, ErrInvalidComponent)
+}
@@ -0,0 +1,403 @@
+package semverutils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrNoConfigFile is returned when no config file is found.
+	ErrNoConfigFile = errors.New("no config file found")
+	// ErrNoConfigSection is returned when a package.json or pyproject.toml has no verscout section.
+	ErrNoConfigSection = errors.New("no verscout section")
+	// ErrConfigExtendsCycle is returned when config files extend each other in a cycle.
+	ErrConfigExtendsCycle = errors.New("config files extend each other in a cycle")
+)
+
+const (
+	// packageJSONFileName is the name of the npm manifest, whose "verscout" key can hold the config.
+	packageJSONFileName = "package.json"
+	// pyprojectFileName is the name of the Python project file, whose "tool.verscout" table can hold the config.
+	pyprojectFileName = "pyproject.toml"
+)
+
+// ConfigFileNames are the names of the config files searched by FindConfigFile, in the order of precedence.
+// A package.json or pyproject.toml is only used if it contains a verscout section, see ErrNoConfigSection.
+var ConfigFileNames = []string{
+	".verscout-config.yaml",
+	".verscout-config.yml",
+	".verscout-config.json",
+	".verscout-config.toml",
+	packageJSONFileName,
+	pyprojectFileName,
+}
+
+// configFile is the structure of the config file. Bumps is a pointer to tell if the file defines any bumps.
+type configFile struct {
+	// Extends is the path of a config file this config file is based on, relative to this config file.
+	Extends            string        `yaml:"extends,omitempty"`
+	Preset             string        `yaml:"preset"`
+	Bumps              *BumpPatterns `yaml:"bumps"`
+	InitialDevelopment bool          `yaml:"initialDevelopment"`
+	TagFormat          string        `yaml:"tagFormat"`
+	TagPattern         string        `yaml:"tagPattern"`
+	Components         []Component   `yaml:"components"`
+	Groups             []Group       `yaml:"groups"`
+	Discover           []string      `yaml:"discover"`
+}
+
+// configOrigin is the config file that sets a key, used to locate errors caused by the value of the key.
+type configOrigin struct {
+	path string
+	root *yaml.Node
+}
+
+// resolvedConfig is the result of merging a config file with the config files it extends.
+type resolvedConfig struct {
+	config configFile
+	bumps  BumpPatterns
+	// bumpsSet tells if any of the config files sets a preset or bumps, otherwise the default bumps are used.
+	bumpsSet bool
+	// sources maps the keys of the config to the source of their value. See CompiledBumpConfig.Source.
+	sources map[string]string
+	origins map[string]configOrigin
+}
+
+// FindConfigFile returns the path of the first config file found in the directory or its parents,
+// trying the ConfigFileNames in each directory.
+// Returns ErrNoConfigFile if there is none.
+func FindConfigFile(directory string) (string, error) {
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path of %s: %w", directory, err)
+	}
+
+	for searched := directory; ; searched = filepath.Dir(searched) {
+		for _, name := range ConfigFileNames {
+			configFilePath := filepath.Join(searched, name)
+
+			info, err := os.Stat(configFilePath)
+			if err != nil || info.IsDir() {
+				continue
+			}
+
+			if name == packageJSONFileName || name == pyprojectFileName {
+				_, err = readConfigNode(configFilePath)
+				if errors.Is(err, ErrNoConfigSection) {
+					continue
+				}
+
+				if err != nil {
+					return "", err
+				}
+			}
+
+			log.WithField("configFile", configFilePath).Info("Found config file")
+
+			return configFilePath, nil
+		}
+
+		if filepath.Dir(searched) == searched {
+			return "", fmt.Errorf("%w in %s or its parent directories", ErrNoConfigFile, directory)
+		}
+	}
+}
+
+// LoadBumpConfigFromFile loads a BumpConfig from a config file and compiles it, see BumpConfig.Compile.
+// The file is read as TOML if its extension is ".toml", as JSON if it is ".json", and as YAML otherwise.
+// For a package.json or pyproject.toml, the verscout section is used, see ErrNoConfigSection.
+// If the file extends another config file, the keys of the file override the ones of the extended file.
+// If the file selects a preset or extends another config file, its bumps are layered on top of the bumps
+// of the preset or extended file, see layerBumps. Otherwise, if the file does not define any bumps,
+// the default bump patterns are used.
+// Returns ErrUnknownConfigKey if the file contains a key that is not part of the config,
+// and ErrConfigExtendsCycle if config files extend each other in a cycle.
+// Errors caused by a value of a file are prefixed with the file path and the line and column of the value.
+func LoadBumpConfigFromFile(configFilePath string) (CompiledBumpConfig, error) {
+	resolved, err := loadConfigFile(configFilePath, nil)
+	if err != nil {
+		return CompiledBumpConfig{}, err
+	}
+
+	compiled, err := BumpConfig{
+		Preset:             resolved.config.Preset,
+		Bumps:              resolved.bumps,
+		InitialDevelopment: resolved.config.InitialDevelopment,
+		TagFormat:          resolved.config.TagFormat,
+		TagPattern:         resolved.config.TagPattern,
+		Components:         resolved.config.Components,
+		Groups:             resolved.config.Groups,
+		Discover:           resolved.config.Discover,
+	}.Compile()
+	if err != nil {
+		return CompiledBumpConfig{}, resolved.positionedError(configFilePath, err)
+	}
+
+	compiled.sources = resolved.sources
+
+	return compiled, nil
+}
+
+// loadConfigFile loads the config file, merged with the config files it extends.
+// The extending files are the paths of the config files extending this one, to detect cycles.
+func loadConfigFile(configFilePath string, extending []string) (*resolvedConfig, error) {
+	log.WithField("configFile", configFilePath).Info("Loading config file")
+
+	root, err := readConfigNode(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config configFile
+
+	if root.Kind != 0 {
+		err = checkConfigNode(root, reflect.TypeOf(config))
+		if err != nil {
+			return nil, positionedError(configFilePath, root, err)
+		}
+
+		err = root.Decode(&config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode config file %s: %w", configFilePath, err)
+		}
+	}
+
+	resolved := &resolvedConfig{sources: make(map[string]string), origins: make(map[string]configOrigin)}
+
+	if config.Extends != "" {
+		resolved, err = loadExtendedConfigFile(configFilePath, root, config.Extends, extending)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = resolved.apply(configFilePath, root, config)
+	if err != nil {
+		return nil, positionedError(configFilePath, root, err)
+	}
+
+	return resolved, nil
+}
+
+// loadExtendedConfigFile loads the config file extended by the config file at the path.
+// Returns ErrConfigExtendsCycle if the extended file is already extending.
+func loadExtendedConfigFile(
+	configFilePath string,
+	root *yaml.Node,
+	extends string,
+	extending []string,
+) (*resolvedConfig, error) {
+	absolutePath, err := filepath.Abs(configFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path of %s: %w", configFilePath, err)
+	}
+
+	extending = append(slices.Clone(extending), absolutePath)
+
+	extendedPath := extends
+	if !filepath.IsAbs(extendedPath) {
+		extendedPath = filepath.Join(filepath.Dir(absolutePath), extendedPath)
+	}
+
+	if slices.Contains(extending, extendedPath) {
+		return nil, positionedError(configFilePath, root, &fieldError{
+			path: []string{"extends"},
+			err:  fmt.Errorf("%w: %s", ErrConfigExtendsCycle, strings.Join(append(extending, extendedPath), " -> ")),
+		})
+	}
+
+	resolved, err := loadConfigFile(extendedPath, extending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file extended by %s: %w", configFilePath, err)
+	}
+
+	return resolved, nil
+}
+
+// apply merges the config of the config file at the path into the resolved config.
+// The keys set by the config file override the resolved ones, except the bumps, which are layered on top
+// of the preset of the config file or the resolved bumps, if any. See layerBumps.
+func (resolved *resolvedConfig) apply(configFilePath string, root *yaml.Node, config configFile) error {
+	err := resolved.applyBumps(configFilePath, root, config)
+	if err != nil {
+		return err
+	}
+
+	keys := configKeys(root)
+	merged := reflect.ValueOf(&resolved.config).Elem()
+
+	for index := range merged.NumField() {
+		name, _, _ := strings.Cut(merged.Type().Field(index).Tag.Get("yaml"), ",")
+		if name == "extends" || name == "bumps" || !slices.Contains(keys, name) {
+			continue
+		}
+
+		merged.Field(index).Set(reflect.ValueOf(config).Field(index))
+		resolved.sources[name] = configFilePath
+		resolved.origins[name] = configOrigin{path: configFilePath, root: root}
+	}
+
+	return nil
+}
+
+// applyBumps resolves the bumps of the config file. See apply.
+func (resolved *resolvedConfig) applyBumps(configFilePath string, root *yaml.Node, config configFile) error {
+	bumps, source, layered := DefaultBumpConfig.Bumps, DefaultSource, false
+
+	switch {
+	case config.Preset != "":
+		presetBumps, err := PresetBumps(config.Preset)
+		if err != nil {
+			return &fieldError{path: []string{"preset"}, err: err}
+		}
+
+		bumps, source, layered = presetBumps, "preset "+config.Preset, true
+	case resolved.bumpsSet:
+		bumps, source, layered = resolved.bumps, resolved.sources["bumps"], true
+	}
+
+	if config.Bumps != nil {
+		resolved.origins["bumps"] = configOrigin{path: configFilePath, root: root}
+
+		if layered {
+			var err error
+
+			bumps, err = layerBumps(bumps, *config.Bumps, configKeys(findConfigNode(root, []string{"bumps"})))
+			if err != nil {
+				return err
+			}
+
+			source = configFilePath + " on top of " + source
+		} else {
+			bumps, source = *config.Bumps, configFilePath
+		}
+	}
+
+	resolved.bumps = bumps
+	resolved.bumpsSet = resolved.bumpsSet || config.Preset != "" || config.Bumps != nil
+
+	if resolved.bumpsSet {
+		resolved.sources["bumps"] = source
+	}
+
+	return nil
+}
+
+// positionedError locates the error of the compiled config in the config file setting the value causing it,
+// see positionedError.
+func (resolved *resolvedConfig) positionedError(configFilePath string, err error) error {
+	var fieldErr *fieldError
+	if errors.As(err, &fieldErr) {
+		if origin, found := resolved.origins[fieldErr.path[0]]; found {
+			return positionedError(origin.path, origin.root, err)
+		}
+	}
+
+	return fmt.Errorf("invalid config file %s: %w", configFilePath, err)
+}
+
+// readConfigNode reads the config file into a node. The node has no kind if the file is empty.
+// Returns ErrNoConfigSection if the file is a package.json or pyproject.toml without a verscout section.
+func readConfigNode(configFilePath string) (*yaml.Node, error) {
+	content, err := os.ReadFile(filepath.Clean(configFilePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+
+	fileName := filepath.Base(configFilePath)
+
+	if strings.EqualFold(filepath.Ext(fileName), ".toml") {
+		return readTOMLConfigNode(configFilePath, content)
+	}
+
+	if !strings.EqualFold(filepath.Ext(fileName), ".json") {
+		var root yaml.Node
+
+		err = yaml.Unmarshal(content, &root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode config file %s: %w", configFilePath, err)
+		}
+
+		return &root, nil
+	}
+
+	root, err := readJSONConfigNode(configFilePath, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if fileName != packageJSONFileName {
+		return root, nil
+	}
+
+	section := childConfigNode(rootConfigNode(root), "verscout")
+	if section == nil {
+		return nil, fmt.Errorf("%w in %s", ErrNoConfigSection, configFilePath)
+	}
+
+	return section, nil
+}
+
+// readTOMLConfigNode decodes the content of a TOML config file into a node.
+// The node has no positions, since the TOML decoder does not provide them.
+func readTOMLConfigNode(configFilePath string, content []byte) (*yaml.Node, error) {
+	var document map[string]any
+
+	_, err := toml.Decode(string(content), &document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config file %s: %w", configFilePath, err)
+	}
+
+	if filepath.Base(configFilePath) == pyprojectFileName {
+		tool, _ := document["tool"].(map[string]any)
+
+		section, found := tool["verscout"].(map[string]any)
+		if !found {
+			return nil, fmt.Errorf("%w in %s", ErrNoConfigSection, configFilePath)
+		}
+
+		document = section
+	}
+
+	var root yaml.Node
+
+	if len(document) == 0 {
+		return &root, nil
+	}
+
+	err = root.Encode(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert config file %s: %w", configFilePath, err)
+	}
+
+	return &root, nil
+}
+
+// configKeys returns the keys of the mapping node, or of the mapping of the document node.
+func configKeys(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+
+	mapping := rootConfigNode(node)
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	keys := make([]string, 0, len(mapping.Content)/2)
+
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		keys = append(keys, mapping.Content[index].Value)
+	}
+
+	return keys
+}
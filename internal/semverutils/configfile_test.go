@@ -0,0 +1,293 @@
+package semverutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) string {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestFindConfigFile(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	configPath := writeFile(t, filepath.Join(directory, ".verscout-config.yaml"), "tagFormat: v{version}\n")
+
+	foundPath, err := FindConfigFile(directory)
+	require.NoError(t, err)
+	assert.Equal(t, configPath, foundPath)
+}
+
+func TestFindConfigFile_ParentDirectory(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	configPath := writeFile(t, filepath.Join(directory, ".verscout-config.toml"), "tagFormat = \"v{version}\"\n")
+	subdirectory := filepath.Join(directory, "services", "billing")
+	require.NoError(t, os.MkdirAll(subdirectory, 0o750))
+
+	foundPath, err := FindConfigFile(subdirectory)
+	require.NoError(t, err)
+	assert.Equal(t, configPath, foundPath)
+}
+
+func TestFindConfigFile_Precedence(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeFile(t, filepath.Join(directory, ".verscout-config.json"), "{}\n")
+	configPath := writeFile(t, filepath.Join(directory, ".verscout-config.yml"), "{}\n")
+
+	foundPath, err := FindConfigFile(directory)
+	require.NoError(t, err)
+	assert.Equal(t, configPath, foundPath)
+}
+
+func TestFindConfigFile_PackageJSONWithoutSection(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeFile(t, filepath.Join(directory, "service", "package.json"), "{\"name\": \"service\"}\n")
+	configPath := writeFile(
+		t,
+		filepath.Join(directory, "pyproject.toml"),
+		"[tool.verscout]\ntagFormat = \"v{version}\"\n",
+	)
+
+	foundPath, err := FindConfigFile(filepath.Join(directory, "service"))
+	require.NoError(t, err)
+	assert.Equal(t, configPath, foundPath)
+}
+
+func TestFindConfigFile_NoConfigFile(t *testing.T) {
+	t.Parallel()
+
+	directory := filepath.Join(t.TempDir(), "repository")
+	require.NoError(t, os.MkdirAll(directory, 0o750))
+
+	_, err := FindConfigFile(directory)
+	// The parents of the temporary directory could contain a config file
+	if err != nil {
+		require.ErrorIs(t, err, ErrNoConfigFile)
+	}
+}
+
+func TestLoadBumpConfigFromFile_JSON(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), ".verscout-config.json"),
+		"{\n\t\"tagFormat\": \"release-{version}\",\n\t\"bumps\": {\"minorPatterns\": [\"^feature:\"]}\n}\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "release-{version}", config.TagFormat)
+	assert.Equal(t, []string{"^feature:"}, config.Bumps.MinorPatterns)
+}
+
+func TestLoadBumpConfigFromFile_JSONInvalidPatternPosition(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), ".verscout-config.json"),
+		"{\n  \"bumps\": {\n    \"majorPatterns\": [\"^feat(\"]\n  }\n}\n",
+	)
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrInvalidBumpPattern)
+	assert.ErrorContains(t, err, configPath+":3:23: bumps.majorPatterns[0]: invalid bump pattern")
+}
+
+func TestLoadBumpConfigFromFile_JSONEscapedSlash(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), ".verscout-config.json"),
+		"{\"tagPattern\": \"^api\\/v(?P<version>.+)$\"}\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "^api/v(?P<version>.+)$", config.TagPattern)
+}
+
+func TestLoadBumpConfigFromFile_JSONInvalidSyntax(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), ".verscout-config.json"), "{\"tagFormat\": }\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	assert.ErrorContains(t, err, "failed to decode config file "+configPath)
+}
+
+func TestLoadBumpConfigFromFile_TOML(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), ".verscout-config.toml"),
+		"initialDevelopment = true\n\n[bumps]\npatchPatterns = [\"^fix:\"]\n\n[bumps.types]\nperf = \"patch\"\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.True(t, config.InitialDevelopment)
+	assert.Equal(t, []string{"^fix:"}, config.Bumps.PatchPatterns)
+	assert.Equal(t, map[string]BumpType{"perf": PatchBump}, config.Bumps.Types)
+}
+
+func TestLoadBumpConfigFromFile_TOMLUnknownKey(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), ".verscout-config.toml"), "tag_format = \"v{version}\"\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrUnknownConfigKey)
+	assert.ErrorContains(t, err, "invalid config file "+configPath+": unknown config key: tag_format")
+}
+
+func TestLoadBumpConfigFromFile_PackageJSON(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "package.json"),
+		"{\n  \"name\": \"storefront\",\n  \"verscout\": {\n    \"tagFormat\": \"storefront-v{version}\"\n  }\n}\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "storefront-v{version}", config.TagFormat)
+	assert.Equal(t, configPath, config.Source("tagFormat"))
+}
+
+func TestLoadBumpConfigFromFile_PackageJSONEscapedSlash(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "package.json"),
+		"{\n  \"name\": \"@shop\\/storefront\",\n  \"verscout\": {\"tagFormat\": \"storefront\\/v{version}\"}\n}\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "storefront/v{version}", config.TagFormat)
+}
+
+func TestLoadBumpConfigFromFile_PackageJSONWithoutSection(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), "package.json"), "{\"name\": \"storefront\"}\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrNoConfigSection)
+}
+
+func TestLoadBumpConfigFromFile_Pyproject(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "pyproject.toml"),
+		"[project]\nname = \"billing\"\n\n[tool.verscout]\npreset = \"angular\"\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "angular", config.Preset)
+	assert.Equal(t, MinorBump, config.Bumps.Types["feat"])
+}
+
+func TestLoadBumpConfigFromFile_Extends(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	basePath := writeFile(
+		t,
+		filepath.Join(directory, "shared", "verscout.yaml"),
+		"tagFormat: release-{version}\ninitialDevelopment: true\nbumps:\n  minorPatterns:\n    - \"^feature:\"\n",
+	)
+	configPath := writeFile(
+		t,
+		filepath.Join(directory, "service", ".verscout-config.yaml"),
+		"extends: ../shared/verscout.yaml\ninitialDevelopment: false\nbumps:\n  patchPatterns:\n    - \"^bugfix:\"\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "release-{version}", config.TagFormat)
+	assert.False(t, config.InitialDevelopment)
+	assert.Equal(t, []string{"^feature:"}, config.Bumps.MinorPatterns)
+	assert.Equal(t, []string{"^bugfix:"}, config.Bumps.PatchPatterns)
+	assert.Equal(t, basePath, config.Source("tagFormat"))
+	assert.Equal(t, configPath, config.Source("initialDevelopment"))
+	assert.Equal(t, configPath+" on top of "+basePath, config.Source("bumps"))
+}
+
+func TestLoadBumpConfigFromFile_ExtendsPreset(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeFile(t, filepath.Join(directory, "base.json"), "{\"preset\": \"angular\"}\n")
+	configPath := writeFile(
+		t,
+		filepath.Join(directory, ".verscout-config.yaml"),
+		"extends: base.json\nbumps:\n  types:\n    docs: patch\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "angular", config.Preset)
+	assert.Equal(t, MinorBump, config.Bumps.Types["feat"])
+	assert.Equal(t, PatchBump, config.Bumps.Types["docs"])
+}
+
+func TestLoadBumpConfigFromFile_ExtendsInvalidPatternPosition(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	basePath := writeFile(t, filepath.Join(directory, "base.yaml"), "tagPattern: \"^v(\"\n")
+	configPath := writeFile(t, filepath.Join(directory, ".verscout-config.yaml"), "extends: base.yaml\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, basePath+":1:13: tagPattern")
+}
+
+func TestLoadBumpConfigFromFile_ExtendsMissingFile(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), "extends: missing.yaml\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	assert.ErrorContains(t, err, "failed to load config file extended by "+configPath)
+}
+
+func TestLoadBumpConfigFromFile_ExtendsCycle(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+	writeFile(t, filepath.Join(directory, "base.yaml"), "extends: .verscout-config.yaml\n")
+	configPath := writeFile(t, filepath.Join(directory, ".verscout-config.yaml"), "extends: base.yaml\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrConfigExtendsCycle)
+	assert.ErrorContains(t, err, filepath.Join(directory, "base.yaml")+":1:10: extends")
+}
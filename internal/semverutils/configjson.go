@@ -0,0 +1,108 @@
+package semverutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readJSONConfigNode decodes the content of a JSON config file into a node, keeping the positions of the values
+// for errors. JSON is not parsed as YAML, since YAML rejects some valid JSON, e.g. the escape "\/".
+// The node has no kind if the file is empty.
+func readJSONConfigNode(configFilePath string, content []byte) (*yaml.Node, error) {
+	var root yaml.Node
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return &root, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	value, err := decodeJSONNode(decoder, content)
+	if err != nil {
+		// Error type could be json.SyntaxError
+		return nil, fmt.Errorf("failed to decode config file %s: %w", configFilePath, err)
+	}
+
+	_, err = decoder.Token()
+	if !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode config file %s: unexpected content after the top-level value",
+			configFilePath)
+	}
+
+	root.Kind = yaml.DocumentNode
+	root.Content = []*yaml.Node{value}
+
+	return &root, nil
+}
+
+// decodeJSONNode decodes the next JSON value of the decoder into a node, positioned in the content.
+func decodeJSONNode(decoder *json.Decoder, content []byte) (*yaml.Node, error) {
+	line, column := jsonPosition(content, decoder.InputOffset())
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Wrapped by readJSONConfigNode
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Line: line, Column: column}
+
+	switch value := token.(type) {
+	case json.Delim:
+		node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+		if value == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		}
+
+		// The keys of an object are decoded like values, alternating with them
+		for decoder.More() {
+			child, err := decodeJSONNode(decoder, content)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, child)
+		}
+
+		// Consume the closing delimiter
+		_, err = decoder.Token()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Wrapped by readJSONConfigNode
+		}
+	case string:
+		node.Tag, node.Value = "!!str", value
+	case json.Number:
+		node.Tag, node.Value = "!!float", value.String()
+
+		_, err = value.Int64()
+		if err == nil {
+			node.Tag = "!!int"
+		}
+	case bool:
+		node.Tag, node.Value = "!!bool", strconv.FormatBool(value)
+	case nil:
+		node.Tag, node.Value = "!!null", "null"
+	}
+
+	return node, nil
+}
+
+// jsonPosition returns the line and column of the next JSON value at or after the offset,
+// skipping whitespace and the separators between values. Like YAML positions, both are 1-based.
+func jsonPosition(content []byte, offset int64) (int, int) {
+	start := int(offset)
+	for start < len(content) && bytes.IndexByte([]byte(" \t\r\n,:"), content[start]) >= 0 {
+		start++
+	}
+
+	lineStart := bytes.LastIndexByte(content[:start], '\n') + 1
+
+	return bytes.Count(content[:start], []byte("\n")) + 1, utf8.RuneCount(content[lineStart:start]) + 1
+}
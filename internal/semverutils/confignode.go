@@ -0,0 +1,212 @@
+package semverutils
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownConfigKey is returned when the config file contains a key that is not part of the config.
+var ErrUnknownConfigKey = errors.New("unknown config key")
+
+// textUnmarshalerType is used to check values of types like BumpType before decoding, so their position is known.
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// nodeError is an error caused by a node of the config file.
+type nodeError struct {
+	node *yaml.Node
+	err  error
+}
+
+func (err *nodeError) Error() string {
+	return err.err.Error()
+}
+
+func (err *nodeError) Unwrap() error {
+	return err.err
+}
+
+// checkConfigNode checks that the node only contains keys of the fields of the type, and that values of types
+// implementing encoding.TextUnmarshaler can be unmarshalled.
+// Returns ErrUnknownConfigKey or the error of the unmarshalling wrapped in a nodeError.
+// Values not matching the type are left to the decoding.
+func checkConfigNode(node *yaml.Node, valueType reflect.Type) error {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if node.Kind == yaml.DocumentNode {
+		for _, child := range node.Content {
+			err := checkConfigNode(child, valueType)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if node.Kind == yaml.ScalarNode && reflect.PointerTo(valueType).Implements(textUnmarshalerType) {
+		unmarshaler, _ := reflect.New(valueType).Interface().(encoding.TextUnmarshaler)
+
+		err := unmarshaler.UnmarshalText([]byte(node.Value))
+		if err != nil {
+			return &nodeError{node: node, err: err}
+		}
+
+		return nil
+	}
+
+	switch {
+	case valueType.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		return checkConfigMapping(node, valueType)
+	case valueType.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for index := 1; index < len(node.Content); index += 2 {
+			err := checkConfigNode(node.Content[index], valueType.Elem())
+			if err != nil {
+				return err
+			}
+		}
+	case valueType.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for _, child := range node.Content {
+			err := checkConfigNode(child, valueType.Elem())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkConfigMapping checks the keys and values of a mapping node decoded into the struct type.
+func checkConfigMapping(node *yaml.Node, structType reflect.Type) error {
+	fieldTypes := make(map[string]reflect.Type, structType.NumField())
+
+	for index := range structType.NumField() {
+		field := structType.Field(index)
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			fieldTypes[name] = field.Type
+		}
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		key, value := node.Content[index], node.Content[index+1]
+
+		fieldType, found := fieldTypes[key.Value]
+		if !found {
+			return &nodeError{node: key, err: fmt.Errorf("%w: %s", ErrUnknownConfigKey, key.Value)}
+		}
+
+		err := checkConfigNode(value, fieldType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// positionedError prefixes the error with the path of the config file, and the line and column of the node
+// causing it, if the error is a nodeError or a fieldError whose field is found in the config file.
+// Otherwise, the error is only prefixed with the path of the config file.
+func positionedError(configFilePath string, root *yaml.Node, err error) error {
+	var (
+		node     *yaml.Node
+		nodeErr  *nodeError
+		fieldErr *fieldError
+	)
+
+	switch {
+	case errors.As(err, &nodeErr):
+		node = nodeErr.node
+	case errors.As(err, &fieldErr):
+		node = findConfigNode(root, fieldErr.path)
+	}
+
+	// Nodes of formats without positions, like TOML, have no line
+	if node == nil || node.Line == 0 {
+		return fmt.Errorf("invalid config file %s: %w", configFilePath, err)
+	}
+
+	return fmt.Errorf("%s:%d:%d: %w", configFilePath, node.Line, node.Column, err)
+}
+
+// findConfigNode returns the node of the value at the path of keys and indexes, or the node of the deepest
+// value found on the way. Returns nil if not even the first key is found.
+func findConfigNode(root *yaml.Node, path []string) *yaml.Node {
+	node := rootConfigNode(root)
+
+	var found *yaml.Node
+
+	for _, element := range path {
+		child := childConfigNode(node, element)
+		if child == nil {
+			break
+		}
+
+		node, found = child, child
+	}
+
+	return found
+}
+
+// rootConfigNode returns the top-level node of the document node.
+func rootConfigNode(root *yaml.Node) *yaml.Node {
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+
+	return root
+}
+
+// childConfigNode returns the value of the key of a mapping node, or the element at the index of a sequence node.
+func childConfigNode(node *yaml.Node, element string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			if node.Content[index].Value == element {
+				return node.Content[index+1]
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(element)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index]
+		}
+	}
+
+	return nil
+}
+
+// joinFieldPath joins the path of keys and indexes of a field, e.g. "bumps.majorPatterns[1]".
+func joinFieldPath(path []string) string {
+	var joined strings.Builder
+
+	for _, element := range path {
+		if _, err := strconv.Atoi(element); err == nil {
+			joined.WriteString("[" + element + "]")
+
+			continue
+		}
+
+		if joined.Len() > 0 {
+			joined.WriteString(".")
+		}
+
+		joined.WriteString(element)
+	}
+
+	return joined.String()
+}
@@ -0,0 +1,143 @@
+package semverutils
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ConfigOverrides are config values given outside of the config file, e.g. by flags or environment variables.
+// Only the keys with a source are overridden, see Sources.
+type ConfigOverrides struct {
+	// Preset replaces the bumps of the config with the bumps of the preset, see PresetBumps.
+	Preset string
+	// Bumps are layered on top of the bumps of the config, or of the preset, see layerBumps.
+	Bumps              BumpPatterns
+	InitialDevelopment bool
+	// TagFormat replaces the tag format of the config, and unsets its tag pattern.
+	TagFormat string
+	// TagPattern replaces the tag pattern of the config, and unsets its tag format.
+	TagPattern string
+	// Sources maps the overridden keys to the source of their value, e.g. "tagFormat" to "--tag-format".
+	// Keys of the bumps are prefixed with "bumps.", e.g. "bumps.majorPatterns".
+	Sources map[string]string
+}
+
+// WithOverrides returns a copy of the config with the overrides applied, compiled again, see BumpConfig.Compile.
+// The sources of the overridden keys replace the ones of the config, and the sources of overridden bumps
+// are layered on top of the source of the bumps, see CompiledBumpConfig.Source.
+// Returns ErrUnknownPreset if the preset does not exist, and the errors of BumpConfig.Compile,
+// prefixed with the source of the invalid value.
+func (config CompiledBumpConfig) WithOverrides(overrides ConfigOverrides) (CompiledBumpConfig, error) {
+	if len(overrides.Sources) == 0 {
+		return config, nil
+	}
+
+	bumpConfig := config.BumpConfig
+	sources := maps.Clone(config.sources)
+
+	if sources == nil {
+		sources = make(map[string]string)
+	}
+
+	bumpsSource := config.Source("bumps")
+
+	if source, found := overrides.Sources["preset"]; found {
+		bumps, err := PresetBumps(overrides.Preset)
+		if err != nil {
+			// Error type could be ErrUnknownPreset
+			return CompiledBumpConfig{}, fmt.Errorf("invalid %s: %w", source, err)
+		}
+
+		bumpConfig.Preset = overrides.Preset
+		bumpConfig.Bumps = bumps
+		sources["preset"] = source
+		bumpsSource = "preset " + overrides.Preset
+	}
+
+	var givenBumps, givenBumpsSources []string
+
+	for _, key := range []string{"majorPatterns", "minorPatterns", "patchPatterns", "types", "breaking"} {
+		if source, found := overrides.Sources["bumps."+key]; found {
+			givenBumps = append(givenBumps, key)
+			givenBumpsSources = append(givenBumpsSources, source)
+		}
+	}
+
+	if len(givenBumps) > 0 {
+		bumps, err := layerBumps(bumpConfig.Bumps, overrides.Bumps, givenBumps)
+		if err != nil {
+			return CompiledBumpConfig{}, overrideError(overrides.Sources, err)
+		}
+
+		bumpConfig.Bumps = bumps
+		bumpsSource = strings.Join(givenBumpsSources, ", ") + " on top of " + bumpsSource
+	}
+
+	if bumpsSource != config.Source("bumps") {
+		sources["bumps"] = bumpsSource
+	}
+
+	if source, found := overrides.Sources["initialDevelopment"]; found {
+		bumpConfig.InitialDevelopment = overrides.InitialDevelopment
+		sources["initialDevelopment"] = source
+	}
+
+	applyTagFormatOverrides(&bumpConfig, overrides, sources)
+
+	compiled, err := bumpConfig.Compile()
+	if err != nil {
+		return CompiledBumpConfig{}, overrideError(overrides.Sources, err)
+	}
+
+	compiled.sources = sources
+
+	return compiled, nil
+}
+
+// applyTagFormatOverrides overrides the tag format and the tag pattern. Since only one of them can be used,
+// overriding one unsets the other, unless both are overridden.
+func applyTagFormatOverrides(bumpConfig *BumpConfig, overrides ConfigOverrides, sources map[string]string) {
+	tagFormatSource, tagFormatFound := overrides.Sources["tagFormat"]
+	tagPatternSource, tagPatternFound := overrides.Sources["tagPattern"]
+
+	if tagFormatFound {
+		bumpConfig.TagFormat = overrides.TagFormat
+		sources["tagFormat"] = tagFormatSource
+
+		if !tagPatternFound {
+			bumpConfig.TagPattern = ""
+			sources["tagPattern"] = tagFormatSource
+		}
+	}
+
+	if tagPatternFound {
+		bumpConfig.TagPattern = overrides.TagPattern
+		sources["tagPattern"] = tagPatternSource
+
+		if !tagFormatFound {
+			bumpConfig.TagFormat = ""
+			sources["tagFormat"] = tagPatternSource
+		}
+	}
+}
+
+// overrideError prefixes the error with the source of the invalid value, if it is a fieldError of
+// an overridden key. Otherwise, all sources are named.
+func overrideError(sources map[string]string, err error) error {
+	var fieldErr *fieldError
+	if errors.As(err, &fieldErr) {
+		key := fieldErr.path[0]
+		if key == "bumps" && len(fieldErr.path) > 1 {
+			key += "." + fieldErr.path[1]
+		}
+
+		if source, found := sources[key]; found {
+			return fmt.Errorf("invalid %s: %w", source, err)
+		}
+	}
+
+	return fmt.Errorf("invalid %s: %w", strings.Join(slices.Sorted(maps.Values(sources)), ", "), err)
+}
@@ -0,0 +1,154 @@
+package semverutils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOverrides_NoOverrides(t *testing.T) {
+	t.Parallel()
+
+	config, err := DefaultBumpConfig.Compile()
+	require.NoError(t, err)
+
+	overridden, err := config.WithOverrides(ConfigOverrides{})
+	require.NoError(t, err)
+	assert.Equal(t, config.BumpConfig, overridden.BumpConfig)
+	assert.Equal(t, DefaultSource, overridden.Source("bumps"))
+}
+
+func TestWithOverrides_BumpPatterns(t *testing.T) {
+	t.Parallel()
+
+	config, err := DefaultBumpConfig.Compile()
+	require.NoError(t, err)
+
+	config, err = config.WithOverrides(ConfigOverrides{
+		Bumps:   BumpPatterns{MajorPatterns: []string{"^breaking:"}},
+		Sources: map[string]string{"bumps.majorPatterns": "--major-pattern"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"^breaking:"}, config.Bumps.MajorPatterns)
+	assert.Equal(t, DefaultBumpConfig.Bumps.MinorPatterns, config.Bumps.MinorPatterns)
+	assert.Equal(t, MajorBump, config.patternBumpType("breaking: Remove the API"))
+	assert.Equal(t, "--major-pattern on top of default", config.Source("bumps"))
+}
+
+func TestWithOverrides_TypesAndBreaking(t *testing.T) {
+	t.Parallel()
+
+	config, err := LoadBumpConfigFromFile(writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), "preset: angular\n"))
+	require.NoError(t, err)
+
+	config, err = config.WithOverrides(ConfigOverrides{
+		Bumps: BumpPatterns{Types: map[string]BumpType{"Docs": PatchBump}, Breaking: MinorBump},
+		Sources: map[string]string{
+			"bumps.types":    "--type",
+			"bumps.breaking": "VERSCOUT_BREAKING",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, PatchBump, config.Bumps.Types["docs"])
+	assert.Equal(t, MinorBump, config.Bumps.Types["feat"])
+	assert.Equal(t, MinorBump, config.Bumps.Breaking)
+	assert.Equal(t, "--type, VERSCOUT_BREAKING on top of preset angular", config.Source("bumps"))
+}
+
+func TestWithOverrides_Preset(t *testing.T) {
+	t.Parallel()
+
+	config, err := DefaultBumpConfig.Compile()
+	require.NoError(t, err)
+
+	config, err = config.WithOverrides(ConfigOverrides{
+		Preset:  "angular",
+		Bumps:   BumpPatterns{PatchPatterns: []string{"^chore:"}},
+		Sources: map[string]string{"preset": "--preset", "bumps.patchPatterns": "--patch-pattern"},
+	})
+	require.NoError(t, err)
+
+	bumps, err := PresetBumps("angular")
+	require.NoError(t, err)
+	assert.Equal(t, "angular", config.Preset)
+	assert.Equal(t, bumps.Types, config.Bumps.Types)
+	assert.Equal(t, []string{"^chore:"}, config.Bumps.PatchPatterns)
+	assert.Equal(t, "--preset", config.Source("preset"))
+	assert.Equal(t, "--patch-pattern on top of preset angular", config.Source("bumps"))
+}
+
+func TestWithOverrides_UnknownPreset(t *testing.T) {
+	t.Parallel()
+
+	config, err := DefaultBumpConfig.Compile()
+	require.NoError(t, err)
+
+	_, err = config.WithOverrides(ConfigOverrides{
+		Preset:  "unknown",
+		Sources: map[string]string{"preset": "VERSCOUT_PRESET"},
+	})
+	require.ErrorIs(t, err, ErrUnknownPreset)
+	assert.ErrorContains(t, err, "invalid VERSCOUT_PRESET:")
+}
+
+func TestWithOverrides_InitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	config, err := DefaultBumpConfig.Compile()
+	require.NoError(t, err)
+
+	config, err = config.WithOverrides(ConfigOverrides{
+		InitialDevelopment: true,
+		Sources:            map[string]string{"initialDevelopment": "--initial-development"},
+	})
+	require.NoError(t, err)
+	assert.True(t, config.InitialDevelopment)
+	assert.Equal(t, "--initial-development", config.Source("initialDevelopment"))
+	assert.Equal(t, DefaultSource, config.Source("bumps"))
+}
+
+func TestWithOverrides_TagFormatUnsetsTagPattern(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "config.yaml"),
+		"tagPattern: '^api/v(?P<version>.+)$'\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+
+	config, err = config.WithOverrides(ConfigOverrides{
+		TagFormat: "release-{version}",
+		Sources:   map[string]string{"tagFormat": "--tag-format"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "release-{version}", config.TagFormat)
+	assert.Empty(t, config.TagPattern)
+	assert.Equal(t, "--tag-format", config.Source("tagFormat"))
+	assert.Equal(t, "--tag-format", config.Source("tagPattern"))
+
+	version, err := config.CompiledTagFormat().ExtractVersion("release-1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", version.String())
+}
+
+func TestWithOverrides_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	config, err := DefaultBumpConfig.Compile()
+	require.NoError(t, err)
+
+	_, err = config.WithOverrides(ConfigOverrides{
+		Bumps: BumpPatterns{MinorPatterns: []string{"^feat("}},
+		Sources: map[string]string{
+			"bumps.majorPatterns": "--major-pattern",
+			"bumps.minorPatterns": "VERSCOUT_MINOR_PATTERN",
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidBumpPattern)
+	assert.ErrorContains(t, err, "invalid VERSCOUT_MINOR_PATTERN: bumps.minorPatterns[0]")
+}
@@ -0,0 +1,130 @@
+package semverutils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSource is the source of the config values not set by any config file. See CompiledBumpConfig.Source.
+const DefaultSource = "default"
+
+// configKeyComments describe the keys of the config file.
+var configKeyComments = map[string]string{
+	"preset": "The name of a built-in bump config: " + strings.Join(PresetNames(), ", ") + ".\n" +
+		"The bumps are layered on top of the preset: Patterns given in bumps replace the ones of the preset,\n" +
+		"and the types are added to the ones of the preset.",
+	"bumps": "The bumps caused by the commits since the latest version tag.\n" +
+		"majorPatterns, minorPatterns and patchPatterns are regexes matched against the commit messages.\n" +
+		"types maps conventional commit types, optionally with a scope, to none, patch, minor or major,\n" +
+		"e.g. \"feat(internal): patch\", and takes precedence over the patterns.\n" +
+		"breaking is the bump of breaking changes of conventional commits, and takes precedence over both.",
+	"initialDevelopment": "Lower the bumps while the major version is 0:\n" +
+		"Breaking changes cause a minor bump, and features a patch bump.",
+	"tagFormat": "A template of the version tags, containing the placeholder {version}, e.g. \"release-{version}\".\n" +
+		"If neither tagFormat nor tagPattern is set, tags like 1.2.3 and v1.2.3 are used.",
+	"tagPattern": "A regex of the version tags, with a named group \"version\", e.g. \"^api/v(?P<version>.+)$\".\n" +
+		"Only one of tagFormat and tagPattern can be set.",
+	"components": "The independently versioned parts of a monorepo.\n" +
+		"Each component has a name, and optionally a tagPrefix, paths and dependsOn.",
+	"groups": "Sets of components that are versioned in lockstep.\n" +
+		"Each group has a name and the names of its components.",
+	"discover": "The ecosystems whose workspace manifests define further components: go, npm or cargo.",
+}
+
+// CommentedYAML encodes the config as the content of a config file, with a comment describing each key.
+func (bumpConfig BumpConfig) CommentedYAML() ([]byte, error) {
+	root, err := encodeConfigNode(bumpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	root.HeadComment = "The verscout config, see https://github.com/erNail/verscout#configure-verscout"
+
+	mapping := root.Content[0]
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		mapping.Content[index].HeadComment = configKeyComments[mapping.Content[index].Value]
+	}
+
+	return marshalConfigNode(root)
+}
+
+// SourcedYAML encodes the effective config as the content of a config file, with a comment naming the source
+// of the value of each key. See Source.
+func (config CompiledBumpConfig) SourcedYAML() ([]byte, error) {
+	root, err := encodeConfigNode(config.BumpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := root.Content[0]
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		key, value := mapping.Content[index], mapping.Content[index+1]
+
+		// Comments of keys with values on the same line, like empty sequences, must be set on the value
+		commented := key
+		if value.Kind == yaml.ScalarNode || len(value.Content) == 0 {
+			commented = value
+		}
+
+		commented.LineComment = "source: " + config.Source(key.Value)
+	}
+
+	return marshalConfigNode(root)
+}
+
+// Source returns where the value of the key of the config file comes from, e.g. the path of the config file.
+// Returns DefaultSource if no config file sets the key.
+func (config CompiledBumpConfig) Source(key string) string {
+	if source, found := config.sources[key]; found {
+		return source
+	}
+
+	return DefaultSource
+}
+
+// encodeConfigNode encodes the config into a document node containing all keys of the config file,
+// even the ones with empty values.
+func encodeConfigNode(bumpConfig BumpConfig) (*yaml.Node, error) {
+	var mapping yaml.Node
+
+	err := mapping.Encode(configFile{
+		Preset:             bumpConfig.Preset,
+		Bumps:              &bumpConfig.Bumps,
+		InitialDevelopment: bumpConfig.InitialDevelopment,
+		TagFormat:          bumpConfig.TagFormat,
+		TagPattern:         bumpConfig.TagPattern,
+		Components:         bumpConfig.Components,
+		Groups:             bumpConfig.Groups,
+		Discover:           bumpConfig.Discover,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&mapping}}, nil
+}
+
+// marshalConfigNode marshals the document node as YAML document, indented by two spaces.
+func marshalConfigNode(root *yaml.Node) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("---\n")
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
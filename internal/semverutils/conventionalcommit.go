@@ -0,0 +1,152 @@
+package semverutils
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// breakingChangeTokens are the footer tokens that mark a breaking change.
+var breakingChangeTokens = []string{"BREAKING CHANGE", "BREAKING-CHANGE"}
+
+// headerRegex matches the header of a conventional commit, e.g. "feat(api)!: add endpoint".
+var headerRegex = regexp.MustCompile(`^(\w[\w-]*)(?:\(([^()]*)\))?(!)?:[ \t]*(\S.*)$`)
+
+// footerRegex matches the first line of a footer, e.g. "Refs: #123", "Refs #123" or "BREAKING CHANGE: removed flag".
+// The separator is captured, since the space after the colon is only optional for some tokens, see matchFooter.
+var footerRegex = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z0-9][A-Za-z0-9-]*)(:[ \t]*| #)(.*)$`)
+
+// footerTokensWithoutSpace are the footer tokens that are also recognized without a space after the colon,
+// e.g. "Release-As:3.0.0", compared case-insensitively. Other tokens require it, so a body paragraph starting
+// with e.g. "http://example.com" does not start the footers.
+var footerTokensWithoutSpace = []string{"BREAKING CHANGE", "BREAKING-CHANGE", ReleaseAsTrailer, VersionBumpTrailer}
+
+// Footer is a footer of a commit message, e.g. "Reviewed-by: Jane" or "BREAKING CHANGE: removed flag".
+type Footer struct {
+	Token string
+	// Value can span multiple lines.
+	Value string
+}
+
+// ConventionalCommit is a commit message parsed according to the Conventional Commits 1.0 specification,
+// see https://www.conventionalcommits.org/en/v1.0.0/.
+type ConventionalCommit struct {
+	// Type is the lowercased type, e.g. "feat". Empty if the header does not follow the specification.
+	Type string
+	// Scope is the optional scope, e.g. "api" for "feat(api): add endpoint".
+	Scope string
+	// Breaking is set if the header contains a "!" before the colon, or a footer marks a breaking change.
+	Breaking bool
+	// Description follows the colon of the header. It is the whole header if Type is empty.
+	Description string
+	// Body is the free-form text between the header and the footers.
+	Body string
+	// Footers are the footers in the order of the message, see Footer.
+	Footers []Footer
+}
+
+// ParseConventionalCommit parses the commit message.
+// Messages with a header not following the specification are parsed as well, so their body and footers,
+// e.g. trailers like "Version-Bump: none", can still be used. See ConventionalCommit.IsConventional.
+// The footers start at the first paragraph whose first line is a footer, and a footer's value ends
+// at the next footer.
+func ParseConventionalCommit(message string) ConventionalCommit {
+	lines := strings.Split(normalizeMessage(message), "\n")
+
+	var commit ConventionalCommit
+
+	if match := headerRegex.FindStringSubmatch(lines[0]); match != nil {
+		commit.Type = strings.ToLower(match[1])
+		commit.Scope = strings.TrimSpace(match[2])
+		commit.Breaking = match[3] == "!"
+		commit.Description = strings.TrimSpace(match[4])
+	} else {
+		commit.Description = strings.TrimSpace(lines[0])
+	}
+
+	footerStart := len(lines)
+
+	for index := 2; index < len(lines); index++ {
+		if _, isFooter := matchFooter(lines[index]); isFooter && strings.TrimSpace(lines[index-1]) == "" {
+			footerStart = index
+
+			break
+		}
+	}
+
+	commit.Body = strings.TrimSpace(strings.Join(lines[1:footerStart], "\n"))
+	commit.Footers = parseFooters(lines[footerStart:])
+
+	for _, footer := range commit.Footers {
+		for _, token := range breakingChangeTokens {
+			if footer.Token == token {
+				commit.Breaking = true
+			}
+		}
+	}
+
+	return commit
+}
+
+// IsConventional reports whether the header of the commit message follows the specification.
+func (commit ConventionalCommit) IsConventional() bool {
+	return commit.Type != ""
+}
+
+// Footer returns the value of the last footer with the token, compared case-insensitively,
+// with surrounding whitespace removed.
+func (commit ConventionalCommit) Footer(token string) (string, bool) {
+	for index := len(commit.Footers) - 1; index >= 0; index-- {
+		if strings.EqualFold(commit.Footers[index].Token, token) {
+			return strings.TrimSpace(commit.Footers[index].Value), true
+		}
+	}
+
+	return "", false
+}
+
+// parseFooters parses the footer lines. Lines that do not start a footer continue the value of the previous one.
+func parseFooters(lines []string) []Footer {
+	var footers []Footer
+
+	for _, line := range lines {
+		if footer, isFooter := matchFooter(line); isFooter {
+			footers = append(footers, footer)
+
+			continue
+		}
+
+		if len(footers) > 0 {
+			footers[len(footers)-1].Value += "\n" + line
+		}
+	}
+
+	for index := range footers {
+		footers[index].Value = strings.TrimRight(footers[index].Value, " \t\n")
+	}
+
+	return footers
+}
+
+// matchFooter returns the footer started by the line, if any. The value can be empty, e.g. "BREAKING CHANGE:".
+func matchFooter(line string) (Footer, bool) {
+	match := footerRegex.FindStringSubmatch(line)
+	if match == nil {
+		return Footer{}, false
+	}
+
+	if match[2] == ":" && !slices.ContainsFunc(footerTokensWithoutSpace, func(token string) bool {
+		return strings.EqualFold(token, match[1])
+	}) {
+		return Footer{}, false
+	}
+
+	return Footer{Token: match[1], Value: match[3]}, true
+}
+
+// normalizeMessage converts CRLF line endings to LF, and removes leading empty lines and trailing whitespace.
+func normalizeMessage(message string) string {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+
+	return strings.TrimLeft(strings.TrimRight(message, " \t\n"), "\n")
+}
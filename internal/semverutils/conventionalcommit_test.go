@@ -0,0 +1,175 @@
+package semverutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventionalCommit_Header(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ConventionalCommit{
+		Type:        "feat",
+		Scope:       "api",
+		Description: "add endpoint",
+	}, ParseConventionalCommit("feat(api): add endpoint"))
+}
+
+func TestParseConventionalCommit_BreakingWithScope(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ConventionalCommit{
+		Type:        "fix",
+		Scope:       "parser",
+		Breaking:    true,
+		Description: "reject empty input",
+	}, ParseConventionalCommit("fix(parser)!: reject empty input"))
+}
+
+func TestParseConventionalCommit_TypeIsNotCaseSensitive(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("Feat: add endpoint")
+	assert.Equal(t, "feat", commit.Type)
+	assert.True(t, commit.IsConventional())
+}
+
+func TestParseConventionalCommit_NotConventional(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("Merge branch 'main'\n\nSigned-off-by: Jane <jane@example.com>")
+	assert.False(t, commit.IsConventional())
+	assert.Equal(t, "Merge branch 'main'", commit.Description)
+	assert.Equal(t, []Footer{{Token: "Signed-off-by", Value: "Jane <jane@example.com>"}}, commit.Footers)
+}
+
+func TestParseConventionalCommit_ReferencedTypeInDescription(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("fix: Add feat(scope)!: Some other message")
+	assert.Equal(t, "fix", commit.Type)
+	assert.Empty(t, commit.Scope)
+	assert.False(t, commit.Breaking)
+	assert.Equal(t, "Add feat(scope)!: Some other message", commit.Description)
+}
+
+func TestParseConventionalCommit_BodyAndFooters(t *testing.T) {
+	t.Parallel()
+
+	message := "fix: prevent racing of requests\n\n" +
+		"Introduce a request id.\n\nRemove timeouts.\n\n" +
+		"Reviewed-by: Z\nRefs #123\n"
+
+	assert.Equal(t, ConventionalCommit{
+		Type:        "fix",
+		Description: "prevent racing of requests",
+		Body:        "Introduce a request id.\n\nRemove timeouts.",
+		Footers:     []Footer{{Token: "Reviewed-by", Value: "Z"}, {Token: "Refs", Value: "123"}},
+	}, ParseConventionalCommit(message))
+}
+
+func TestParseConventionalCommit_BreakingChangeFooter(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("feat: allow config to extend other configs\n\n" +
+		"BREAKING CHANGE: `extends` key in config file is now used for extending other config files")
+	assert.True(t, commit.Breaking)
+	assert.Empty(t, commit.Body)
+	assert.Equal(t, "BREAKING CHANGE", commit.Footers[0].Token)
+}
+
+func TestParseConventionalCommit_BreakingChangeFooterWithHyphen(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ParseConventionalCommit("feat: new option\n\nBREAKING-CHANGE: removed old option").Breaking)
+}
+
+func TestParseConventionalCommit_FooterWithoutSpace(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("fix: prepare release\n\nRelease-As:3.0.0")
+	assert.Equal(t, []Footer{{Token: "Release-As", Value: "3.0.0"}}, commit.Footers)
+}
+
+func TestParseConventionalCommit_BodyStartingWithURL(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit(
+		"fix: follow redirects\n\nSee the docs.\n\nhttp://example.com/redirects\nexplains the status codes.\n\n" +
+			"Note:see above\n\nRefs: #123",
+	)
+	assert.Equal(
+		t,
+		"See the docs.\n\nhttp://example.com/redirects\nexplains the status codes.\n\nNote:see above",
+		commit.Body,
+	)
+	assert.Equal(t, []Footer{{Token: "Refs", Value: "#123"}}, commit.Footers)
+}
+
+func TestParseConventionalCommit_GenericFooterRequiresSpace(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("fix: prepare release\n\nRefs:#123")
+	assert.Equal(t, "Refs:#123", commit.Body)
+	assert.Empty(t, commit.Footers)
+}
+
+func TestParseConventionalCommit_EmptyBreakingChangeFooter(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("feat: new option\n\nBREAKING CHANGE:")
+	assert.True(t, commit.Breaking)
+	assert.Equal(t, []Footer{{Token: "BREAKING CHANGE", Value: ""}}, commit.Footers)
+}
+
+func TestParseConventionalCommit_BreakingChangeFooterIsCaseSensitive(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, ParseConventionalCommit("feat: new option\n\nBreaking change: removed old option").Breaking)
+}
+
+func TestParseConventionalCommit_BreakingChangeInBodyIsNoFooter(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("docs: explain flags\n\nA flag removal is a BREAKING CHANGE: see the docs.")
+	assert.False(t, commit.Breaking)
+	assert.Empty(t, commit.Footers)
+}
+
+func TestParseConventionalCommit_MultiLineFooters(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("feat: new option\n\n" +
+		"BREAKING CHANGE: removed old option\n  and its environment variable\n\nPlease migrate.\n" +
+		"Refs: #42")
+	assert.Equal(t, []Footer{
+		{Token: "BREAKING CHANGE", Value: "removed old option\n  and its environment variable\n\nPlease migrate."},
+		{Token: "Refs", Value: "#42"},
+	}, commit.Footers)
+}
+
+func TestParseConventionalCommit_CRLF(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ConventionalCommit{
+		Type:        "fix",
+		Breaking:    true,
+		Description: "bug",
+		Body:        "Some body.",
+		Footers:     []Footer{{Token: "BREAKING CHANGE", Value: "removed flag"}},
+	}, ParseConventionalCommit("fix: bug\r\n\r\nSome body.\r\n\r\nBREAKING CHANGE: removed flag\r\n"))
+}
+
+func TestConventionalCommitFooter(t *testing.T) {
+	t.Parallel()
+
+	commit := ParseConventionalCommit("fix: bug\n\nRelease-As: 2.0.0\nrelease-as: 3.0.0 ")
+
+	value, found := commit.Footer(ReleaseAsTrailer)
+	assert.True(t, found)
+	assert.Equal(t, "3.0.0", value)
+
+	_, found = commit.Footer(VersionBumpTrailer)
+	assert.False(t, found)
+}
@@ -0,0 +1,72 @@
+package semverutils
+
+import (
+	"fmt"
+)
+
+// Group is a set of components that are versioned in lockstep, e.g. a CLI and its SDK.
+// The members always get the same next version, based on the highest bump of any member.
+type Group struct {
+	// Name identifies the group, e.g. "cli".
+	Name string `yaml:"name"`
+	// Components are the names of the members of the group.
+	Components []string `yaml:"components"`
+}
+
+// GroupOf returns the group the component with the given name belongs to, or nil if it is not part of a group.
+func (bumpConfig BumpConfig) GroupOf(componentName string) *Group {
+	for index := range bumpConfig.Groups {
+		for _, member := range bumpConfig.Groups[index].Components {
+			if member == componentName {
+				return &bumpConfig.Groups[index]
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateGroups checks that every group has a unique name,
+// and that its members are existing components that are not part of another group.
+// Returns ErrInvalidComponent otherwise.
+func validateGroups(groups []Group, components []Component) error {
+	componentNames := make(map[string]bool, len(components))
+	for _, component := range components {
+		componentNames[component.Name] = true
+	}
+
+	groupNames := make(map[string]bool, len(groups))
+	groupOfComponent := make(map[string]string)
+
+	for _, group := range groups {
+		if group.Name == "" {
+			return fmt.Errorf("%w: every group needs a name", ErrInvalidComponent)
+		}
+
+		if groupNames[group.Name] {
+			return fmt.Errorf("%w: group %s is defined more than once", ErrInvalidComponent, group.Name)
+		}
+
+		groupNames[group.Name] = true
+
+		for _, member := range group.Components {
+			if !componentNames[member] {
+				return fmt.Errorf("%w: group %s contains unknown component %s", ErrInvalidComponent, group.Name, member)
+			}
+
+			if otherGroup, found := groupOfComponent[member]; found {
+				return fmt.Errorf(
+					"%w: %s is part of the groups %s and %s",
+					ErrInvalidComponent,
+					member,
+					otherGroup,
+					group.Name,
+				)
+			}
+
+			groupOfComponent[member] = group.Name
+		}
+	}
+
+	return nil
+}
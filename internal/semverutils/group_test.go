@@ -0,0 +1,142 @@
+package semverutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpConfigGroupOf(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{Groups: []Group{{Name: "cli", Components: []string{"cli", "sdk"}}}}
+
+	group := config.GroupOf("sdk")
+	require.NotNil(t, group)
+	assert.Equal(t, "cli", group.Name)
+	assert.Nil(t, config.GroupOf("billing"))
+}
+
+func TestLoadBumpConfigFromFile_Groups(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - name: cli
+  - name: sdk
+groups:
+  - name: cli
+    components:
+      - cli
+      - sdk
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	config, err := LoadBumpConfigFromFile(tmpFile)
+	require.NoError(t, err)
+	assert.Equal(t, []Group{{Name: "cli", Components: []string{"cli", "sdk"}}}, config.Groups)
+}
+
+func TestLoadBumpConfigFromFile_GroupWithUnknownComponent(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - name: cli
+groups:
+  - name: cli
+    components:
+      - cli
+      - sdk
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	_, err = LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidComponent)
+}
+
+func TestLoadBumpConfigFromFile_ComponentInTwoGroups(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+components:
+  - name: cli
+  - name: sdk
+groups:
+  - name: cli
+    components:
+      - cli
+      - sdk
+  - name: sdk
+    components:
+      - sdk
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	err := os.WriteFile(tmpFile, []byte(yamlContent), 0o600)
+	require.NoError(t, err)
+
+	_, err = LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidComponent)
+}
+
+func TestPropagateBumps_Group(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{{Name: "cli"}, {Name: "sdk"}, {Name: "docs"}}
+	groups := []Group{{Name: "tools", Components: []string{"cli", "sdk", "docs"}}}
+
+	bumps, propagations := PropagateBumps(
+		components,
+		groups,
+		map[string]BumpType{"cli": PatchBump, "sdk": MinorBump},
+	)
+	assert.Equal(t, map[string]BumpType{"cli": MinorBump, "sdk": MinorBump, "docs": MinorBump}, bumps)
+	assert.Equal(t, "versioned in lockstep with group tools", propagations["cli"].Reason())
+	assert.NotContains(t, propagations, "sdk")
+}
+
+func TestPropagateBumps_GroupMemberDependency(t *testing.T) {
+	t.Parallel()
+
+	components := []Component{
+		{Name: "app", DependsOn: []string{"cli"}},
+		{Name: "cli"},
+		{Name: "sdk"},
+	}
+	groups := []Group{{Name: "tools", Components: []string{"cli", "sdk"}}}
+
+	bumps, propagations := PropagateBumps(components, groups, map[string]BumpType{"sdk": MajorBump})
+	assert.Equal(t, map[string]BumpType{"app": PatchBump, "cli": MajorBump, "sdk": MajorBump}, bumps)
+	assert.Equal(t, "depends on cli, which is bumped", propagations["app"].Reason())
+}
+
+func TestBumpConfigRelatedComponents_Group(t *testing.T) {
+	t.Parallel()
+
+	config := BumpConfig{
+		Components: []Component{
+			{Name: "shared"},
+			{Name: "cli"},
+			{Name: "sdk", DependsOn: []string{"shared"}},
+			{Name: "billing"},
+		},
+		Groups: []Group{{Name: "tools", Components: []string{"cli", "sdk"}}},
+	}
+
+	components, err := config.RelatedComponents("cli")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+
+	assert.Equal(t, []string{"cli", "shared", "sdk"}, names)
+}
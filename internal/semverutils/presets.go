@@ -0,0 +1,115 @@
+package semverutils
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownPreset is returned when a preset is not one of PresetNames.
+var ErrUnknownPreset = errors.New("unknown preset")
+
+// presets are the built-in bumps that a config file can select with the preset key.
+var presets = map[string]BumpPatterns{
+	// The Conventional Commits rules of DefaultBumpConfig
+	"default": DefaultBumpConfig.Bumps,
+	// The commit message guidelines of Angular, e.g. "feat(forms): add validator",
+	// see https://github.com/angular/angular/blob/main/contributing-docs/commit-message-guidelines.md
+	"angular": {
+		Breaking: MajorBump,
+		Types: map[string]BumpType{
+			"feat":     MinorBump,
+			"fix":      PatchBump,
+			"perf":     PatchBump,
+			"build":    NoBump,
+			"ci":       NoBump,
+			"docs":     NoBump,
+			"refactor": NoBump,
+			"style":    NoBump,
+			"test":     NoBump,
+		},
+	},
+	// The commit message format of ESLint, e.g. "Fix: crash on empty file (fixes #123)",
+	// see https://eslint.org/docs/latest/contribute/pull-requests#commit-messages
+	"eslint": {
+		MajorPatterns: []string{`^Breaking:`},
+		MinorPatterns: []string{`^(New|Update):`},
+		PatchPatterns: []string{`^Fix:`},
+	},
+	// The emoji prefixes of the Atom commit message guidelines, e.g. ":bug: Fix crash on empty file",
+	// see https://github.com/atom/atom/blob/master/CONTRIBUTING.md#git-commit-messages.
+	// The guidelines only mark fixes, so there are no minor or major bumps.
+	"atom": {
+		PatchPatterns: []string{`^:(bug|racehorse|non-potable_water|lock|penguin|apple|checkered_flag):`},
+	},
+	// The emojis of gitmoji, either as code or as emoji, e.g. ":sparkles: Add search" or "✨ Add search",
+	// see https://gitmoji.dev
+	"gitmoji": {
+		MajorPatterns: []string{`^(:boom:|💥)`},
+		MinorPatterns: []string{`^(:sparkles:|✨)`},
+		PatchPatterns: []string{`^(:bug:|🐛|:ambulance:|🚑\x{FE0F}?|:lock:|🔒\x{FE0F}?|:zap:|⚡\x{FE0F}?)`},
+	},
+}
+
+// PresetNames returns the names of the presets in alphabetical order.
+func PresetNames() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
+
+// PresetBumps returns the bumps of the preset with the given name.
+// Returns ErrUnknownPreset if there is no such preset.
+func PresetBumps(name string) (BumpPatterns, error) {
+	preset, found := presets[name]
+	if !found {
+		return BumpPatterns{}, fmt.Errorf(
+			"%w: %q, expected one of %s",
+			ErrUnknownPreset,
+			name,
+			strings.Join(PresetNames(), ", "),
+		)
+	}
+
+	preset.Types = maps.Clone(preset.Types)
+
+	return preset, nil
+}
+
+// layerBumps returns the bumps with the overrides on top: The patterns of each bump type given by the overrides
+// replace the ones of the bumps, and the types of the overrides are added to the ones of the bumps.
+// The patterns given are the keys of BumpPatterns set in the config file, e.g. "majorPatterns".
+func layerBumps(bumps BumpPatterns, overrides BumpPatterns, givenPatterns []string) (BumpPatterns, error) {
+	if slices.Contains(givenPatterns, "majorPatterns") {
+		bumps.MajorPatterns = overrides.MajorPatterns
+	}
+
+	if slices.Contains(givenPatterns, "minorPatterns") {
+		bumps.MinorPatterns = overrides.MinorPatterns
+	}
+
+	if slices.Contains(givenPatterns, "patchPatterns") {
+		bumps.PatchPatterns = overrides.PatchPatterns
+	}
+
+	if slices.Contains(givenPatterns, "breaking") {
+		bumps.Breaking = overrides.Breaking
+	}
+
+	// Normalize the types first, so rules only differing in case override each other
+	types, err := normalizeTypes(overrides.Types)
+	if err != nil {
+		return BumpPatterns{}, err
+	}
+
+	if len(types) > 0 {
+		bumps.Types = maps.Clone(bumps.Types)
+		if bumps.Types == nil {
+			bumps.Types = make(map[string]BumpType, len(types))
+		}
+
+		maps.Copy(bumps.Types, types)
+	}
+
+	return bumps, nil
+}
@@ -0,0 +1,187 @@
+package semverutils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// presetBumpType returns the bump type the commit message causes with the bumps of the preset.
+func presetBumpType(t *testing.T, preset string, message string) BumpType {
+	t.Helper()
+
+	bumps, err := PresetBumps(preset)
+	require.NoError(t, err)
+
+	return DetermineBumpType([]string{message}, compileBumpConfig(t, BumpConfig{Bumps: bumps}))
+}
+
+func TestPresetNames(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"angular", "atom", "default", "eslint", "gitmoji"}, PresetNames())
+}
+
+func TestPresetBumps_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := PresetBumps("jquery")
+	require.ErrorIs(t, err, ErrUnknownPreset)
+}
+
+func TestPresetBumps_ReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	bumps, err := PresetBumps("angular")
+	require.NoError(t, err)
+
+	bumps.Types["docs"] = PatchBump
+
+	assert.Equal(t, NoBump, presetBumpType(t, "angular", "docs: readme"))
+}
+
+func TestPreset_Angular(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MinorBump, presetBumpType(t, "angular", "feat(forms): add validator"))
+	assert.Equal(t, PatchBump, presetBumpType(t, "angular", "perf(core): faster change detection"))
+	assert.Equal(t, NoBump, presetBumpType(t, "angular", "docs: readme"))
+	assert.Equal(t, MajorBump, presetBumpType(t, "angular", "fix: api\n\nBREAKING CHANGE: removed option"))
+}
+
+func TestPreset_ESLint(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MajorBump, presetBumpType(t, "eslint", "Breaking: drop Node.js 12 (fixes #1)"))
+	assert.Equal(t, MinorBump, presetBumpType(t, "eslint", "New: no-foo rule (fixes #2)"))
+	assert.Equal(t, MinorBump, presetBumpType(t, "eslint", "Update: add option to no-bar (fixes #3)"))
+	assert.Equal(t, PatchBump, presetBumpType(t, "eslint", "Fix: crash on empty file (fixes #4)"))
+	assert.Equal(t, NoBump, presetBumpType(t, "eslint", "Docs: fix typo"))
+}
+
+func TestPreset_Atom(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, PatchBump, presetBumpType(t, "atom", ":bug: Fix crash on empty file"))
+	assert.Equal(t, PatchBump, presetBumpType(t, "atom", ":racehorse: Improve startup time"))
+	assert.Equal(t, NoBump, presetBumpType(t, "atom", ":memo: Update docs"))
+}
+
+func TestPreset_Gitmoji(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MajorBump, presetBumpType(t, "gitmoji", ":boom: Remove deprecated API"))
+	assert.Equal(t, MajorBump, presetBumpType(t, "gitmoji", "💥 Remove deprecated API"))
+	assert.Equal(t, MinorBump, presetBumpType(t, "gitmoji", ":sparkles: Add search"))
+	assert.Equal(t, MinorBump, presetBumpType(t, "gitmoji", "✨ Add search"))
+	assert.Equal(t, PatchBump, presetBumpType(t, "gitmoji", ":bug: Fix crash"))
+	assert.Equal(t, PatchBump, presetBumpType(t, "gitmoji", "🚑️ Fix critical crash"))
+	assert.Equal(t, NoBump, presetBumpType(t, "gitmoji", ":memo: Update docs"))
+}
+
+func TestLoadBumpConfigFromFile_Preset(t *testing.T) {
+	t.Parallel()
+
+	config, err := LoadBumpConfigFromFile(writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), "preset: gitmoji\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "gitmoji", config.Preset)
+	assert.Equal(t, MinorBump, DetermineBumpType([]string{"✨ Add search"}, config))
+	assert.Equal(t, "preset gitmoji", config.Source("bumps"))
+}
+
+func TestLoadBumpConfigFromFile_PresetWithOverrides(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), `
+preset: gitmoji
+bumps:
+  patchPatterns:
+    - "^(:bug:|🐛|:memo:)"
+  types:
+    fix: patch
+`)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+
+	gitmoji, err := PresetBumps("gitmoji")
+	require.NoError(t, err)
+	assert.Equal(t, BumpPatterns{
+		MajorPatterns: gitmoji.MajorPatterns,
+		MinorPatterns: gitmoji.MinorPatterns,
+		PatchPatterns: []string{"^(:bug:|🐛|:memo:)"},
+		Types:         map[string]BumpType{"fix": PatchBump},
+	}, config.Bumps)
+	assert.Equal(t, configPath+" on top of preset gitmoji", config.Source("bumps"))
+}
+
+func TestLoadBumpConfigFromFile_PresetTypesMerged(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "config.yaml"),
+		"preset: angular\nbumps:\n  types:\n    Docs: patch\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, PatchBump, config.Bumps.Types["docs"])
+	assert.Equal(t, MinorBump, config.Bumps.Types["feat"])
+}
+
+func TestLoadBumpConfigFromFile_UnknownPreset(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(t, filepath.Join(t.TempDir(), "config.yaml"), "tagFormat: v{version}\npreset: jquery\n")
+
+	_, err := LoadBumpConfigFromFile(configPath)
+	require.ErrorIs(t, err, ErrUnknownPreset)
+	assert.ErrorContains(t, err, configPath+":2:9: preset: unknown preset")
+}
+
+func TestLoadBumpConfigFromFile_BumpsWithoutBreakingRule(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "config.yaml"),
+		"bumps:\n  minorPatterns:\n    - \"^feat:\"\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, NoBump, config.Bumps.Breaking)
+}
+
+func TestLoadBumpConfigFromFile_PresetBreakingRule(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeFile(
+		t,
+		filepath.Join(t.TempDir(), "config.yaml"),
+		"preset: angular\nbumps:\n  breaking: minor\n",
+	)
+
+	config, err := LoadBumpConfigFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, MinorBump, config.Bumps.Breaking)
+	assert.Equal(t, MinorBump, config.Bumps.Types["feat"])
+}
+
+func TestCalculateNextVersion_AngularPresetEmptyBreakingChangeFooter(t *testing.T) {
+	t.Parallel()
+
+	bumps, err := PresetBumps("angular")
+	require.NoError(t, err)
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.0",
+		[]string{"feat: new option\n\nBREAKING CHANGE:\nThe old option is gone."},
+		compileBumpConfig(t, BumpConfig{Bumps: bumps}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
@@ -4,6 +4,7 @@
 package semverutils
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"regexp"
@@ -20,13 +21,41 @@ var (
 	ErrNoBump = errors.New("no conventional commits found that affect the version")
 	// ErrInvalidSemVerTag is returned when a version tag doesn't follow semantic versioning format.
 	ErrInvalidSemVerTag = errors.New("invalid semantic version tag")
+	// ErrInvalidPreReleaseChannel is returned when a pre-release channel is not a valid pre-release identifier.
+	ErrInvalidPreReleaseChannel = errors.New("invalid pre-release channel")
+	// ErrNotPreRelease is returned when a version is expected to be a pre-release, but is not.
+	ErrNotPreRelease = errors.New("version is not a pre-release")
+	// ErrNotInitialDevelopment is returned when a version is expected to have the major version 0, but has not.
+	ErrNotInitialDevelopment = errors.New("version is not in initial development")
+	// ErrInvalidBumpType is returned when a bump type name is unknown.
+	ErrInvalidBumpType = errors.New("invalid bump type")
+	// ErrVersionNotGreater is returned when a requested version is not greater than the latest version.
+	ErrVersionNotGreater = errors.New("version is not greater than the latest version")
 )
 
-// SemVer represents a semantic version with major, minor, and patch components.
+// preReleaseChannelRegex matches a single alphanumeric pre-release identifier that can be used as a channel name.
+var preReleaseChannelRegex = regexp.MustCompile(`^[0-9A-Za-z-]*[A-Za-z-][0-9A-Za-z-]*$`)
+
+// numericIdentifierRegex matches a numeric pre-release identifier.
+var numericIdentifierRegex = regexp.MustCompile(`^[0-9]+$`)
+
+// semVerPattern matches a version following the SemVer 2.0 grammar, without any anchors.
+// The capture groups are major, minor, patch, pre-release and build metadata.
+const semVerPattern = `(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`
+
+// semVerRegex matches a version following the SemVer 2.0 grammar, optionally prefixed with 'v'.
+var semVerRegex = regexp.MustCompile(`^v?` + semVerPattern + `$`)
+
+// SemVer represents a semantic version with major, minor, and patch components,
+// as well as optional pre-release identifiers and build metadata.
 type SemVer struct {
-	Major int
-	Minor int
-	Patch int
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease []string
+	Build      []string
 }
 
 // BumpType represents the type of version bump to perform.
@@ -40,38 +69,172 @@ const (
 	MajorBump
 )
 
+// String returns the name of the bump type, e.g. "minor".
+func (bumpType BumpType) String() string {
+	switch bumpType {
+	case MajorBump:
+		return "major"
+	case MinorBump:
+		return "minor"
+	case PatchBump:
+		return "patch"
+	case NoBump:
+		return "none"
+	}
+
+	return fmt.Sprintf("BumpType(%d)", int(bumpType))
+}
+
+// ParseBumpType returns the bump type with the given name, e.g. "minor". See BumpType.String.
+// Returns ErrInvalidBumpType if the name is unknown.
+func ParseBumpType(name string) (BumpType, error) {
+	for _, bumpType := range []BumpType{NoBump, PatchBump, MinorBump, MajorBump} {
+		if bumpType.String() == name {
+			return bumpType, nil
+		}
+	}
+
+	return NoBump, fmt.Errorf("%w: %q, expected one of none, patch, minor or major", ErrInvalidBumpType, name)
+}
+
+// MarshalText returns the name of the bump type, see BumpType.String.
+func (bumpType BumpType) MarshalText() ([]byte, error) {
+	return []byte(bumpType.String()), nil
+}
+
+// UnmarshalText parses the name of a bump type, see ParseBumpType.
+func (bumpType *BumpType) UnmarshalText(text []byte) error {
+	parsed, err := ParseBumpType(string(text))
+	if err != nil {
+		return err
+	}
+
+	*bumpType = parsed
+
+	return nil
+}
+
 // IsValidSemVerTag checks if the provided string is a valid semantic version tag.
-// The tag may optionally start with 'v' and must follow the format X.Y.Z where X, Y, and Z are non-negative integers.
+// The tag may optionally start with 'v' and must follow the SemVer 2.0 format X.Y.Z[-PRERELEASE][+BUILD],
+// where X, Y, and Z are non-negative integers without leading zeros.
 func IsValidSemVerTag(semVerString string) bool {
-	semVerRegex := regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
-
 	return semVerRegex.MatchString(semVerString)
 }
 
 // ExtractSemVerStruct parses a version tag string and returns a SemVer struct.
 // Returns ErrInvalidSemVerTag if the tag does not follow semantic versioning format.
 func ExtractSemVerStruct(versionTag string) (*SemVer, error) {
-	if !IsValidSemVerTag(versionTag) {
+	matches := semVerRegex.FindStringSubmatch(versionTag)
+	if matches == nil {
 		return nil, ErrInvalidSemVerTag
 	}
 
-	version := regexp.MustCompile(`\d+\.\d+\.\d+`).FindString(versionTag)
+	numbers := make([]int, 0, 3)
+
+	for _, part := range matches[1:4] {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSemVerTag, err)
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	semVer := &SemVer{
+		Major: numbers[0],
+		Minor: numbers[1],
+		Patch: numbers[2],
+	}
 
-	parts := strings.Split(version, ".")
-	major, _ := strconv.Atoi(parts[0])
-	minor, _ := strconv.Atoi(parts[1])
-	patch, _ := strconv.Atoi(parts[2])
+	if matches[4] != "" {
+		semVer.PreRelease = strings.Split(matches[4], ".")
+	}
+
+	if matches[5] != "" {
+		semVer.Build = strings.Split(matches[5], ".")
+	}
 
-	return &SemVer{
-		Major: major,
-		Minor: minor,
-		Patch: patch,
-	}, nil
+	return semVer, nil
 }
 
-// String returns the string representation of a SemVer in the format X.Y.Z.
+// String returns the string representation of a SemVer in the format X.Y.Z[-PRERELEASE][+BUILD].
 func (semVer *SemVer) String() string {
-	return fmt.Sprintf("%d.%d.%d", semVer.Major, semVer.Minor, semVer.Patch)
+	version := fmt.Sprintf("%d.%d.%d", semVer.Major, semVer.Minor, semVer.Patch)
+
+	if len(semVer.PreRelease) > 0 {
+		version += "-" + strings.Join(semVer.PreRelease, ".")
+	}
+
+	if len(semVer.Build) > 0 {
+		version += "+" + strings.Join(semVer.Build, ".")
+	}
+
+	return version
+}
+
+// Compare compares the precedence of two versions as defined by SemVer 2.0.
+// It returns -1 if semVer has lower precedence than other, 1 if it has higher precedence,
+// and 0 if both have the same precedence.
+// The version core is compared numerically. A pre-release has lower precedence than its release.
+// Pre-release identifiers are compared one by one: numeric identifiers numerically, alphanumeric
+// identifiers in ASCII order, and numeric identifiers have lower precedence than alphanumeric ones.
+// A larger set of pre-release identifiers has higher precedence if all preceding identifiers are equal.
+// Build metadata is ignored.
+func (semVer *SemVer) Compare(other *SemVer) int {
+	if result := cmp.Compare(semVer.Major, other.Major); result != 0 {
+		return result
+	}
+
+	if result := cmp.Compare(semVer.Minor, other.Minor); result != 0 {
+		return result
+	}
+
+	if result := cmp.Compare(semVer.Patch, other.Patch); result != 0 {
+		return result
+	}
+
+	switch {
+	case !semVer.IsPreRelease() && !other.IsPreRelease():
+		return 0
+	case !semVer.IsPreRelease():
+		return 1
+	case !other.IsPreRelease():
+		return -1
+	}
+
+	for index := 0; index < len(semVer.PreRelease) && index < len(other.PreRelease); index++ {
+		if result := comparePreReleaseIdentifiers(semVer.PreRelease[index], other.PreRelease[index]); result != 0 {
+			return result
+		}
+	}
+
+	return cmp.Compare(len(semVer.PreRelease), len(other.PreRelease))
+}
+
+func comparePreReleaseIdentifiers(identifier string, other string) int {
+	isNumeric := numericIdentifierRegex.MatchString(identifier)
+	otherIsNumeric := numericIdentifierRegex.MatchString(other)
+
+	switch {
+	case isNumeric && otherIsNumeric:
+		// Numeric identifiers have no leading zeros, so a longer identifier is always the larger number
+		if result := cmp.Compare(len(identifier), len(other)); result != 0 {
+			return result
+		}
+
+		return strings.Compare(identifier, other)
+	case isNumeric:
+		return -1
+	case otherIsNumeric:
+		return 1
+	default:
+		return strings.Compare(identifier, other)
+	}
+}
+
+// IsPreRelease reports whether the version carries pre-release identifiers.
+func (semVer *SemVer) IsPreRelease() bool {
+	return len(semVer.PreRelease) > 0
 }
 
 // CalculateNextVersion determines the next semantic version based on the current version
@@ -79,7 +242,11 @@ func (semVer *SemVer) String() string {
 // Returns ErrNoCommitsFound if the commit list is empty.
 // Returns ErrNoBump if no version bump is required.
 // Returns ErrInvalidSemVerTag if the tag does not follow semantic versioning format.
-func CalculateNextVersion(versionTag string, commitMessages []string, bumpConfig BumpConfig) (string, error) {
+func CalculateNextVersion(
+	versionTag string,
+	commitMessages []string,
+	bumpConfig CompiledBumpConfig,
+) (string, error) {
 	if len(commitMessages) == 0 {
 		return "", ErrNoCommitsFound
 	}
@@ -90,7 +257,28 @@ func CalculateNextVersion(versionTag string, commitMessages []string, bumpConfig
 		return "", fmt.Errorf("failed to extract SemVer struct: %w", err)
 	}
 
-	bumpType := determineBumpType(commitMessages, bumpConfig)
+	if pinnedVersion := PinnedVersion(semVer, commitMessages); pinnedVersion != nil {
+		log.WithField("version", pinnedVersion.String()).Infof("Using version pinned by %s trailer", ReleaseAsTrailer)
+
+		return pinnedVersion.String(), nil
+	}
+
+	bumpType := bumpConfig.EffectiveBumpType(semVer, DetermineBumpType(commitMessages, bumpConfig))
+
+	// Error type could be ErrNoBump
+	return BumpVersion(versionTag, bumpType)
+}
+
+// BumpVersion applies the bump type to the version, e.g. a minor bump turns 1.4.2 into 1.5.0.
+// Returns ErrNoBump if the bump type is NoBump.
+// Returns ErrInvalidSemVerTag if the tag does not follow semantic versioning format.
+func BumpVersion(versionTag string, bumpType BumpType) (string, error) {
+	semVer, err := ExtractSemVerStruct(versionTag)
+	if err != nil {
+		// Error type could be ErrInvalidSemVerTag
+		return "", fmt.Errorf("failed to extract SemVer struct: %w", err)
+	}
+
 	if bumpType == NoBump {
 		return "", ErrNoBump
 	}
@@ -100,43 +288,200 @@ func CalculateNextVersion(versionTag string, commitMessages []string, bumpConfig
 	return nextSemVer.String(), nil
 }
 
-func determineBumpType(commitMessages []string, bumpConfig BumpConfig) BumpType {
-	bumpType := NoBump
+// GraduateVersion returns 1.0.0, the first stable version after the initial development of a version 0.y.z.
+// Returns ErrNotInitialDevelopment if the major version is not 0.
+// Returns ErrInvalidSemVerTag if the tag does not follow semantic versioning format.
+func GraduateVersion(versionTag string) (string, error) {
+	semVer, err := ExtractSemVerStruct(versionTag)
+	if err != nil {
+		// Error type could be ErrInvalidSemVerTag
+		return "", fmt.Errorf("failed to extract SemVer struct: %w", err)
+	}
 
-	for _, message := range commitMessages {
-		for _, pattern := range bumpConfig.Bumps.MajorPatterns {
-			if regexp.MustCompile(pattern).MatchString(message) {
-				log.WithField("commitMessage", message).Info("Detected bump type: MAJOR")
+	if semVer.Major != 0 {
+		return "", fmt.Errorf("%w: %s", ErrNotInitialDevelopment, versionTag)
+	}
+
+	stableSemVer := SemVer{Major: 1}
+
+	return stableSemVer.String(), nil
+}
+
+// SetVersion returns the requested version, if it is greater than the version tag.
+// Returns ErrVersionNotGreater if the requested version is not greater than the version tag.
+// Returns ErrInvalidSemVerTag if either version does not follow semantic versioning format.
+func SetVersion(versionTag string, requestedVersion string) (string, error) {
+	semVer, err := ExtractSemVerStruct(versionTag)
+	if err != nil {
+		// Error type could be ErrInvalidSemVerTag
+		return "", fmt.Errorf("failed to extract SemVer struct: %w", err)
+	}
+
+	requestedSemVer, err := ExtractSemVerStruct(requestedVersion)
+	if err != nil {
+		// Error type could be ErrInvalidSemVerTag
+		return "", fmt.Errorf("failed to extract SemVer struct of requested version: %w", err)
+	}
+
+	if requestedSemVer.Compare(semVer) <= 0 {
+		return "", fmt.Errorf("%w: %s is not greater than %s", ErrVersionNotGreater, requestedVersion, versionTag)
+	}
+
+	return requestedSemVer.String(), nil
+}
 
-				return MajorBump
-			}
+// CalculateNextPreReleaseVersion turns a release version into a pre-release on the given channel,
+// e.g. 1.5.0 on channel "rc" becomes 1.5.0-rc.1.
+// The counter continues from the highest existing version in the format X.Y.Z-CHANNEL.N
+// with the same version core, so repeated pre-releases result in 1.5.0-rc.2, 1.5.0-rc.3, and so on.
+// Returns ErrInvalidPreReleaseChannel if the channel is not a valid alphanumeric pre-release identifier.
+// Returns ErrInvalidSemVerTag if the release version does not follow semantic versioning format.
+func CalculateNextPreReleaseVersion(releaseVersion string, channel string, existingVersions []SemVer) (string, error) {
+	if !preReleaseChannelRegex.MatchString(channel) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPreReleaseChannel, channel)
+	}
+
+	semVer, err := ExtractSemVerStruct(releaseVersion)
+	if err != nil {
+		// Error type could be ErrInvalidSemVerTag
+		return "", fmt.Errorf("failed to extract SemVer struct: %w", err)
+	}
+
+	counter := 0
+
+	for _, existing := range existingVersions {
+		if existing.Major != semVer.Major || existing.Minor != semVer.Minor || existing.Patch != semVer.Patch {
+			continue
+		}
+
+		if len(existing.PreRelease) != 2 || existing.PreRelease[0] != channel {
+			continue
 		}
 
-		for _, pattern := range bumpConfig.Bumps.MinorPatterns {
-			if regexp.MustCompile(pattern).MatchString(message) {
-				if bumpType < MinorBump {
-					log.WithField("commitMessage", message).Info("Detected bump type: MINOR")
+		existingCounter, err := strconv.Atoi(existing.PreRelease[1])
+		if err != nil {
+			continue
+		}
+
+		counter = max(counter, existingCounter)
+	}
+
+	nextSemVer := SemVer{
+		Major:      semVer.Major,
+		Minor:      semVer.Minor,
+		Patch:      semVer.Patch,
+		PreRelease: []string{channel, strconv.Itoa(counter + 1)},
+	}
+
+	return nextSemVer.String(), nil
+}
+
+// PromoteVersion returns the release version that a pre-release version tag was meant to become,
+// e.g. 2.0.0-beta.3 is promoted to 2.0.0. No bump is applied.
+// Returns ErrNotPreRelease if the tag is not a pre-release.
+// Returns ErrInvalidSemVerTag if the tag does not follow semantic versioning format.
+func PromoteVersion(versionTag string) (string, error) {
+	semVer, err := ExtractSemVerStruct(versionTag)
+	if err != nil {
+		// Error type could be ErrInvalidSemVerTag
+		return "", fmt.Errorf("failed to extract SemVer struct: %w", err)
+	}
+
+	if !semVer.IsPreRelease() {
+		return "", fmt.Errorf("%w: %s", ErrNotPreRelease, versionTag)
+	}
+
+	semVer.PreRelease = nil
+	semVer.Build = nil
+
+	return semVer.String(), nil
+}
+
+// DetermineBumpType returns the highest bump type caused by any of the commit messages.
+// The bump type of a commit is given by its VersionBumpTrailer, or by the patterns of the bump config otherwise.
+func DetermineBumpType(commitMessages []string, bumpConfig CompiledBumpConfig) BumpType {
+	bumpType := NoBump
 
-					bumpType = MinorBump
-				}
-			}
+	for _, message := range commitMessages {
+		commitBumpType := determineCommitBumpType(message, bumpConfig)
+		if commitBumpType <= bumpType {
+			continue
 		}
 
-		for _, pattern := range bumpConfig.Bumps.PatchPatterns {
-			if regexp.MustCompile(pattern).MatchString(message) {
-				if bumpType < PatchBump {
-					log.WithField("commitMessage", message).Info("Detected bump type: PATCH")
+		log.WithField("commitMessage", message).
+			Infof("Detected bump type: %s", strings.ToUpper(commitBumpType.String()))
 
-					bumpType = PatchBump
-				}
-			}
+		bumpType = commitBumpType
+		if bumpType == MajorBump {
+			return bumpType
 		}
 	}
 
 	return bumpType
 }
 
+// determineCommitBumpType returns the bump type caused by a single commit message.
+// Breaking changes cause the bump type of the breaking rule, see BumpPatterns.Breaking.
+// Otherwise the type rules are applied, see BumpPatterns.TypeBumpType, and if no rule exists for the commit,
+// the patterns are matched against the message with normalized line endings.
+func determineCommitBumpType(message string, bumpConfig CompiledBumpConfig) BumpType {
+	commit := ParseConventionalCommit(message)
+
+	bumpType, overridden, err := versionBumpOverride(commit)
+	if err != nil {
+		log.WithField("commitMessage", message).Warnf("Ignoring %s trailer: %v", VersionBumpTrailer, err)
+	} else if overridden {
+		log.WithFields(log.Fields{"commitMessage": message, "bumpType": bumpType}).
+			Infof("Using bump type of %s trailer instead of the patterns", VersionBumpTrailer)
+
+		return bumpType
+	}
+
+	if commit.Breaking && bumpConfig.Bumps.Breaking != NoBump {
+		return bumpConfig.Bumps.Breaking
+	}
+
+	if bumpType, found := bumpConfig.Bumps.TypeBumpType(commit); found {
+		return bumpType
+	}
+
+	return bumpConfig.patternBumpType(normalizeMessage(message))
+}
+
+// EffectiveBumpType returns the bump type to apply to the version.
+// If InitialDevelopment is enabled and the major version is 0, a major bump becomes a minor bump,
+// and a minor bump becomes a patch bump, since SemVer allows breaking changes in minor versions before 1.0.0.
+func (bumpConfig BumpConfig) EffectiveBumpType(semVer *SemVer, bumpType BumpType) BumpType {
+	if !bumpConfig.InitialDevelopment || semVer.Major != 0 || bumpType < MinorBump {
+		return bumpType
+	}
+
+	effectiveBumpType := bumpType - 1
+
+	log.WithFields(log.Fields{"version": semVer.String(), "from": bumpType, "to": effectiveBumpType}).
+		Info("Lowered bump type during initial development")
+
+	return effectiveBumpType
+}
+
+// applyBump increments the version according to the bump type.
+// Any pre-release identifiers and build metadata are dropped, since the result is a new release.
+// A pre-release version is only incremented if the bump type is greater than the bump its release version
+// already implies, e.g. a fix after 1.4.0-rc.1 results in 1.4.0, but a feature after 1.4.1-rc.1 in 1.5.0.
 func applyBump(semVer SemVer, bumpType BumpType) SemVer {
+	if bumpType == NoBump {
+		return semVer
+	}
+
+	isPreRelease := len(semVer.PreRelease) > 0
+
+	semVer.PreRelease = nil
+	semVer.Build = nil
+
+	if isPreRelease && bumpType <= impliedBumpType(semVer) {
+		return semVer
+	}
+
 	switch bumpType {
 	case MajorBump:
 		semVer.Major++
@@ -152,3 +497,18 @@ func applyBump(semVer SemVer, bumpType BumpType) SemVer {
 
 	return semVer
 }
+
+// impliedBumpType returns the bump type that results in the version, e.g. a minor bump for 1.4.0.
+// No bump results in 0.0.0.
+func impliedBumpType(semVer SemVer) BumpType {
+	switch {
+	case semVer.Patch != 0:
+		return PatchBump
+	case semVer.Minor != 0:
+		return MinorBump
+	case semVer.Major != 0:
+		return MajorBump
+	default:
+		return NoBump
+	}
+}
@@ -16,6 +16,17 @@ func TestIsValidSemVerTag_Valid(t *testing.T) {
 	assert.True(t, IsValidSemVerTag("v1.2.3"))
 }
 
+func TestIsValidSemVerTag_ValidPreReleaseAndBuild(t *testing.T) {
+	t.Parallel()
+	assert.True(t, IsValidSemVerTag("1.4.0-rc.1"))
+	assert.True(t, IsValidSemVerTag("v1.4.0-rc.1"))
+	assert.True(t, IsValidSemVerTag("1.4.0+build.7"))
+	assert.True(t, IsValidSemVerTag("1.4.0-alpha+001"))
+	assert.True(t, IsValidSemVerTag("1.0.0-x-y-z.--"))
+	assert.True(t, IsValidSemVerTag("1.0.0-0.3.7"))
+	assert.True(t, IsValidSemVerTag("1.0.0+21AF26D3----117B344092BD"))
+}
+
 func TestIsValidSemVerTag_Invalid(t *testing.T) {
 	t.Parallel()
 	assert.False(t, IsValidSemVerTag("1.0"))
@@ -24,6 +35,17 @@ func TestIsValidSemVerTag_Invalid(t *testing.T) {
 	assert.False(t, IsValidSemVerTag("a.b.c"))
 }
 
+func TestIsValidSemVerTag_InvalidPreReleaseAndBuild(t *testing.T) {
+	t.Parallel()
+	assert.False(t, IsValidSemVerTag("01.0.0"))
+	assert.False(t, IsValidSemVerTag("1.0.0-"))
+	assert.False(t, IsValidSemVerTag("1.0.0-rc..1"))
+	assert.False(t, IsValidSemVerTag("1.0.0-01"))
+	assert.False(t, IsValidSemVerTag("1.0.0+"))
+	assert.False(t, IsValidSemVerTag("1.0.0+build+7"))
+	assert.False(t, IsValidSemVerTag("1.0.0-rc_1"))
+}
+
 func TestExtractSemVerStruct_Valid(t *testing.T) {
 	t.Parallel()
 
@@ -40,6 +62,36 @@ func TestExtractSemVerStruct_VPrefix(t *testing.T) {
 	assert.Equal(t, &SemVer{Major: 1, Minor: 2, Patch: 3}, semVer)
 }
 
+func TestExtractSemVerStruct_PreReleaseAndBuild(t *testing.T) {
+	t.Parallel()
+
+	semVer, err := ExtractSemVerStruct("v1.4.0-rc.1+build.7")
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&SemVer{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"rc", "1"}, Build: []string{"build", "7"}},
+		semVer,
+	)
+	assert.True(t, semVer.IsPreRelease())
+}
+
+func TestExtractSemVerStruct_BuildOnly(t *testing.T) {
+	t.Parallel()
+
+	semVer, err := ExtractSemVerStruct("1.4.0+build.7")
+	require.NoError(t, err)
+	assert.Equal(t, &SemVer{Major: 1, Minor: 4, Patch: 0, Build: []string{"build", "7"}}, semVer)
+	assert.False(t, semVer.IsPreRelease())
+}
+
+func TestExtractSemVerStruct_NumberOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	semVer, err := ExtractSemVerStruct("99999999999999999999.0.0")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+	assert.Nil(t, semVer)
+}
+
 func TestExtractSemVerStruct_InvalidFormat(t *testing.T) {
 	t.Parallel()
 
@@ -59,12 +111,21 @@ func TestSemVerString(t *testing.T) {
 
 	semVer = &SemVer{Major: 2, Minor: 0, Patch: 1}
 	assert.Equal(t, "2.0.1", semVer.String())
+
+	semVer = &SemVer{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"rc", "1"}}
+	assert.Equal(t, "1.4.0-rc.1", semVer.String())
+
+	semVer = &SemVer{Major: 1, Minor: 4, Patch: 0, Build: []string{"build", "7"}}
+	assert.Equal(t, "1.4.0+build.7", semVer.String())
+
+	semVer = &SemVer{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"beta"}, Build: []string{"sha", "5114f85"}}
+	assert.Equal(t, "1.4.0-beta+sha.5114f85", semVer.String())
 }
 
 func TestCalculateNextVersion_BugFix(t *testing.T) {
 	t.Parallel()
 
-	nextVersion, err := CalculateNextVersion("1.0.0", []string{"fix: bug fix"}, DefaultBumpConfig)
+	nextVersion, err := CalculateNextVersion("1.0.0", []string{"fix: bug fix"}, compileBumpConfig(t, DefaultBumpConfig))
 	require.NoError(t, err)
 	assert.Equal(t, "1.0.1", nextVersion)
 }
@@ -72,7 +133,11 @@ func TestCalculateNextVersion_BugFix(t *testing.T) {
 func TestCalculateNextVersion_NewFeature(t *testing.T) {
 	t.Parallel()
 
-	nextVersion, err := CalculateNextVersion("1.0.0", []string{"feat: new feature"}, DefaultBumpConfig)
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"feat: new feature"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
 	require.NoError(t, err)
 	assert.Equal(t, "1.1.0", nextVersion)
 }
@@ -80,7 +145,11 @@ func TestCalculateNextVersion_NewFeature(t *testing.T) {
 func TestCalculateNextVersion_BugFixAndNewFeature(t *testing.T) {
 	t.Parallel()
 
-	nextVersion, err := CalculateNextVersion("1.0.0", []string{"fix: bug fix", "feat: new feature"}, DefaultBumpConfig)
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"fix: bug fix", "feat: new feature"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
 	require.NoError(t, err)
 	assert.Equal(t, "1.1.0", nextVersion)
 }
@@ -91,7 +160,7 @@ func TestCalculateNextVersion_BugFixAndBreakingChange(t *testing.T) {
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"fix: bug fix\n\nBREAKING CHANGE: major update"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
@@ -103,7 +172,7 @@ func TestCalculateNextVersion_NewFeatureAndBreakingChange(t *testing.T) {
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"feat: new feature\n\nBREAKING CHANGE: major update"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
@@ -115,7 +184,7 @@ func TestCalculateNextVersion_BugFixNewFeatureAndBreakingChange(t *testing.T) {
 	nextVersion, err := CalculateNextVersion("1.0.0", []string{
 		"fix: bug fix",
 		"feat: new feature\n\nBREAKING CHANGE: major update",
-	}, DefaultBumpConfig)
+	}, compileBumpConfig(t, DefaultBumpConfig))
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
 }
@@ -126,7 +195,7 @@ func TestCalculateNextVersion_NewFixAndBreakingChangeInType(t *testing.T) {
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"fix!: new feature"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
@@ -138,7 +207,7 @@ func TestCalculateNextVersion_NewFeatureAndBreakingChangeInType(t *testing.T) {
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"feat!: new feature"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
@@ -150,7 +219,7 @@ func TestCalculateNextVersion_NewFeatureWithScopeAndBreakingChangeInType(t *test
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"feat(scope)!: new feature"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
@@ -162,7 +231,7 @@ func TestCalculateNextVersion_NewCustomTypeAndBreakingChangeInType(t *testing.T)
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"custom!: new change"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
@@ -174,7 +243,7 @@ func TestCalculateNextVersion_NewRefactorChangeAndBreakingChangeInType(t *testin
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"refactor!: new change"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
@@ -186,7 +255,7 @@ func TestCalculateNextVersion_FixTypeWithOtherTypeReferencedInTitle(t *testing.T
 	nextVersion, err := CalculateNextVersion(
 		"1.0.0",
 		[]string{"fix: Add feat(scope)!: Some other message"},
-		DefaultBumpConfig,
+		compileBumpConfig(t, DefaultBumpConfig),
 	)
 	require.NoError(t, err)
 	assert.Equal(t, "1.0.1", nextVersion)
@@ -212,7 +281,7 @@ func TestCalculateNextVersion_CustomConfig_BugFixAndBreakingChange(t *testing.T)
 	nextVersion, err := CalculateNextVersion("1.0.0", []string{
 		"fix: bug fix",
 		"feat: new feature\n\nBREAK: major update",
-	}, bumpConfig)
+	}, compileBumpConfig(t, bumpConfig))
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", nextVersion)
 }
@@ -237,7 +306,7 @@ func TestCalculateNextVersion_CustomConfig_BugFixAndFeature(t *testing.T) {
 	nextVersion, err := CalculateNextVersion("1.0.0", []string{
 		"patch: bug fix",
 		"feature: new feature",
-	}, bumpConfig)
+	}, compileBumpConfig(t, bumpConfig))
 	require.NoError(t, err)
 	assert.Equal(t, "1.1.0", nextVersion)
 }
@@ -261,15 +330,67 @@ func TestCalculateNextVersion_CustomConfig_BugFix(t *testing.T) {
 
 	nextVersion, err := CalculateNextVersion("1.0.0", []string{
 		"patch: bug fix",
-	}, bumpConfig)
+	}, compileBumpConfig(t, bumpConfig))
 	require.NoError(t, err)
 	assert.Equal(t, "1.0.1", nextVersion)
 }
 
+func TestCalculateNextVersion_PreReleaseTag(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.4.0-rc.1+build.7",
+		[]string{"fix: bug fix"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.4.0", nextVersion)
+}
+
+func TestCalculateNextVersion_PreReleaseTagBreakingChange(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"2.0.0-rc.1",
+		[]string{"feat!: drop the v1 API"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
+
+func TestCalculateNextVersion_PreReleaseTagGreaterBump(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.4.1-rc.1",
+		[]string{"feat: new feature"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0", nextVersion)
+}
+
+func TestCalculateNextVersion_ZeroPreReleaseTag(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"0.0.0-rc.1",
+		[]string{"feat: new feature"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", nextVersion)
+}
+
 func TestCalculateNextVersion_ChoreCommit(t *testing.T) {
 	t.Parallel()
 
-	nextVersion, err := CalculateNextVersion("1.0.0", []string{"chore: update readme"}, DefaultBumpConfig)
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"chore: update readme"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
 	require.ErrorIs(t, err, ErrNoBump)
 	assert.Empty(t, nextVersion)
 }
@@ -277,7 +398,7 @@ func TestCalculateNextVersion_ChoreCommit(t *testing.T) {
 func TestCalculateNextVersion_NoCommits(t *testing.T) {
 	t.Parallel()
 
-	nextVersion, err := CalculateNextVersion("1.0.0", []string{}, DefaultBumpConfig)
+	nextVersion, err := CalculateNextVersion("1.0.0", []string{}, compileBumpConfig(t, DefaultBumpConfig))
 	require.ErrorIs(t, err, ErrNoCommitsFound)
 	assert.Empty(t, nextVersion)
 }
@@ -285,7 +406,361 @@ func TestCalculateNextVersion_NoCommits(t *testing.T) {
 func TestCalculateNextVersion_InvalidSemVerTag(t *testing.T) {
 	t.Parallel()
 
-	nextVersion, err := CalculateNextVersion("invalid", []string{"fix: bug fix"}, DefaultBumpConfig)
+	nextVersion, err := CalculateNextVersion(
+		"invalid",
+		[]string{"fix: bug fix"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
 	require.ErrorIs(t, err, ErrInvalidSemVerTag)
 	assert.Empty(t, nextVersion)
 }
+
+func TestBumpVersion_Minor(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := BumpVersion("1.2.3-rc.1", MinorBump)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", nextVersion)
+}
+
+func TestBumpVersion_NoBump(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := BumpVersion("1.2.3", NoBump)
+	require.ErrorIs(t, err, ErrNoBump)
+	assert.Empty(t, nextVersion)
+}
+
+func TestBumpVersion_InvalidSemVerTag(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := BumpVersion("invalid", PatchBump)
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+	assert.Empty(t, nextVersion)
+}
+
+func TestBumpTypeString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "none", NoBump.String())
+	assert.Equal(t, "patch", PatchBump.String())
+	assert.Equal(t, "minor", MinorBump.String())
+	assert.Equal(t, "major", MajorBump.String())
+}
+
+func TestCalculateNextVersion_InitialDevelopment_BreakingChange(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultBumpConfig
+	config.InitialDevelopment = true
+
+	nextVersion, err := CalculateNextVersion("0.4.2", []string{"feat!: remove endpoint"}, compileBumpConfig(t, config))
+	require.NoError(t, err)
+	assert.Equal(t, "0.5.0", nextVersion)
+}
+
+func TestCalculateNextVersion_InitialDevelopment_NewFeature(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultBumpConfig
+	config.InitialDevelopment = true
+
+	nextVersion, err := CalculateNextVersion("0.4.2", []string{"feat: new endpoint"}, compileBumpConfig(t, config))
+	require.NoError(t, err)
+	assert.Equal(t, "0.4.3", nextVersion)
+}
+
+func TestCalculateNextVersion_InitialDevelopment_BugFix(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultBumpConfig
+	config.InitialDevelopment = true
+
+	nextVersion, err := CalculateNextVersion("0.4.2", []string{"fix: bug fix"}, compileBumpConfig(t, config))
+	require.NoError(t, err)
+	assert.Equal(t, "0.4.3", nextVersion)
+}
+
+func TestCalculateNextVersion_InitialDevelopment_StableVersion(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultBumpConfig
+	config.InitialDevelopment = true
+
+	nextVersion, err := CalculateNextVersion("1.4.2", []string{"feat!: remove endpoint"}, compileBumpConfig(t, config))
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
+
+func TestCalculateNextVersion_MajorZeroWithoutInitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"0.4.2",
+		[]string{"feat!: remove endpoint"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", nextVersion)
+}
+
+func TestGraduateVersion(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := GraduateVersion("0.9.3-rc.1")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", nextVersion)
+}
+
+func TestGraduateVersion_NotInitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := GraduateVersion("1.2.0")
+	require.ErrorIs(t, err, ErrNotInitialDevelopment)
+	assert.Empty(t, nextVersion)
+}
+
+func TestGraduateVersion_InvalidSemVerTag(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := GraduateVersion("invalid")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+	assert.Empty(t, nextVersion)
+}
+
+func TestParseBumpType(t *testing.T) {
+	t.Parallel()
+
+	bumpType, err := ParseBumpType("minor")
+	require.NoError(t, err)
+	assert.Equal(t, MinorBump, bumpType)
+
+	bumpType, err = ParseBumpType("none")
+	require.NoError(t, err)
+	assert.Equal(t, NoBump, bumpType)
+}
+
+func TestParseBumpType_Invalid(t *testing.T) {
+	t.Parallel()
+
+	bumpType, err := ParseBumpType("MAJOR")
+	require.ErrorIs(t, err, ErrInvalidBumpType)
+	assert.Equal(t, NoBump, bumpType)
+}
+
+func TestSetVersion(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := SetVersion("1.4.2", "v2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
+
+func TestSetVersion_NotGreater(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := SetVersion("1.4.2", "1.4.2-rc.1")
+	require.ErrorIs(t, err, ErrVersionNotGreater)
+	assert.Empty(t, nextVersion)
+}
+
+func TestSetVersion_InvalidRequestedVersion(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := SetVersion("1.4.2", "2.0")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+	assert.Empty(t, nextVersion)
+}
+
+func TestCalculateNextPreReleaseVersion_FirstPreRelease(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextPreReleaseVersion("1.5.0", "rc", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0-rc.1", nextVersion)
+}
+
+func TestCalculateNextPreReleaseVersion_ContinuesCounter(t *testing.T) {
+	t.Parallel()
+
+	existingVersions := []SemVer{
+		{Major: 1, Minor: 4, Patch: 0},
+		{Major: 1, Minor: 5, Patch: 0, PreRelease: []string{"rc", "1"}},
+		{Major: 1, Minor: 5, Patch: 0, PreRelease: []string{"rc", "2"}},
+	}
+
+	nextVersion, err := CalculateNextPreReleaseVersion("1.5.0", "rc", existingVersions)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0-rc.3", nextVersion)
+}
+
+func TestCalculateNextPreReleaseVersion_IgnoresOtherChannelsAndVersions(t *testing.T) {
+	t.Parallel()
+
+	existingVersions := []SemVer{
+		{Major: 1, Minor: 5, Patch: 0, PreRelease: []string{"beta", "4"}},
+		{Major: 1, Minor: 4, Patch: 0, PreRelease: []string{"rc", "7"}},
+		{Major: 1, Minor: 5, Patch: 0, PreRelease: []string{"rc", "x"}},
+		{Major: 1, Minor: 5, Patch: 0, PreRelease: []string{"rc"}},
+	}
+
+	nextVersion, err := CalculateNextPreReleaseVersion("1.5.0", "rc", existingVersions)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0-rc.1", nextVersion)
+}
+
+func TestCalculateNextPreReleaseVersion_InvalidChannel(t *testing.T) {
+	t.Parallel()
+
+	for _, channel := range []string{"", "rc.1", "rc_1", "42"} {
+		nextVersion, err := CalculateNextPreReleaseVersion("1.5.0", channel, nil)
+		require.ErrorIs(t, err, ErrInvalidPreReleaseChannel, channel)
+		assert.Empty(t, nextVersion)
+	}
+}
+
+func TestCalculateNextPreReleaseVersion_InvalidReleaseVersion(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextPreReleaseVersion("invalid", "rc", nil)
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+	assert.Empty(t, nextVersion)
+}
+
+func TestPromoteVersion_PreRelease(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := PromoteVersion("v2.0.0-beta.3+build.7")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
+
+func TestPromoteVersion_NotPreRelease(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := PromoteVersion("2.0.0")
+	require.ErrorIs(t, err, ErrNotPreRelease)
+	assert.Empty(t, nextVersion)
+}
+
+func TestPromoteVersion_InvalidSemVerTag(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := PromoteVersion("invalid")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+	assert.Empty(t, nextVersion)
+}
+
+func TestSemVerCompare_PrecedenceOrder(t *testing.T) {
+	t.Parallel()
+
+	// Ordered by ascending precedence, taken from the SemVer 2.0 specification
+	orderedVersions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for index := range len(orderedVersions) - 1 {
+		lower, err := ExtractSemVerStruct(orderedVersions[index])
+		require.NoError(t, err)
+		higher, err := ExtractSemVerStruct(orderedVersions[index+1])
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, lower.Compare(higher), "%s < %s", lower, higher)
+		assert.Equal(t, 1, higher.Compare(lower), "%s > %s", higher, lower)
+	}
+}
+
+func TestSemVerCompare_IgnoresBuildMetadataAndPrefix(t *testing.T) {
+	t.Parallel()
+
+	semVer, err := ExtractSemVerStruct("v1.0.0+build.1")
+	require.NoError(t, err)
+	other, err := ExtractSemVerStruct("1.0.0+build.2")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, semVer.Compare(other))
+}
+
+func TestSemVerCompare_LargeNumericIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	semVer, err := ExtractSemVerStruct("1.0.0-rc.99999999999999999999")
+	require.NoError(t, err)
+	other, err := ExtractSemVerStruct("1.0.0-rc.100000000000000000000")
+	require.NoError(t, err)
+
+	assert.Equal(t, -1, semVer.Compare(other))
+}
+
+func TestCalculateNextVersion_CRLFBreakingChangeFooter(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"fix: bug\r\n\r\nBREAKING-CHANGE: removed flag\r\n"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
+
+func TestCalculateNextVersion_CRLFFeature(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"feat(api): endpoint\r\n\r\nBody.\r\n"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", nextVersion)
+}
+
+func TestCalculateNextVersion_CustomConfig_BreakingRule(t *testing.T) {
+	t.Parallel()
+
+	bumpConfig := BumpConfig{
+		Bumps: BumpPatterns{
+			MinorPatterns: []string{`^feature:`},
+			Breaking:      MajorBump,
+		},
+	}
+
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"feature!: new feature"},
+		compileBumpConfig(t, bumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
+
+func TestCalculateNextVersion_CustomConfig_NoBreakingRule(t *testing.T) {
+	t.Parallel()
+
+	// Without major patterns and a breaking rule, e.g. to stay on 0.x, breaking changes cause no major bump
+	bumpConfig := BumpConfig{
+		Bumps: BumpPatterns{
+			MinorPatterns: []string{`^feat(\(.*\))?!?:`},
+		},
+	}
+
+	nextVersion, err := CalculateNextVersion(
+		"0.3.0",
+		[]string{"feat!: new feature\n\nBREAKING CHANGE: the old API is gone"},
+		compileBumpConfig(t, bumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "0.4.0", nextVersion)
+}
@@ -0,0 +1,122 @@
+package semverutils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// VersionPlaceholder is the placeholder for the version in a tag format template, e.g. "release-{version}".
+const VersionPlaceholder = "{version}"
+
+// versionGroupName is the name of the regex group that captures the version in a tag pattern.
+const versionGroupName = "version"
+
+// ErrInvalidTagFormat is returned when a tag format template or tag pattern cannot be used.
+var ErrInvalidTagFormat = errors.New("invalid tag format")
+
+// TagFormat describes how version tags are named, e.g. "v1.2.3", "release-1.2.3" or "api/v1.2.3".
+// It is used to recognize version tags, and to render the next version as a tag name.
+type TagFormat struct {
+	pattern  *regexp.Regexp
+	template string
+}
+
+// DefaultTagFormat recognizes tags in the format MAJOR.MINOR.PATCH, optionally prefixed with 'v'.
+// Versions are rendered with the prefix of the latest tag, e.g. v1.2.4 after v1.2.3, and 1.2.4 after 1.2.3.
+var DefaultTagFormat = &TagFormat{
+	pattern: regexp.MustCompile(`^v?(?P<version>` + semVerPattern + `)$`),
+}
+
+// NewTagFormat creates a TagFormat from either a template or a regex pattern.
+// A template contains the placeholder "{version}" once, e.g. "release-{version}".
+// A pattern is a regex with a named group "version" that captures the version, e.g. `^api/v(?P<version>.+)$`.
+// If both are empty, DefaultTagFormat is returned.
+// Returns ErrInvalidTagFormat if both are set, the template does not contain the placeholder exactly once,
+// or the pattern is not a valid regex with a "version" group.
+func NewTagFormat(template string, pattern string) (*TagFormat, error) {
+	switch {
+	case template != "" && pattern != "":
+		return nil, fmt.Errorf("%w: only one of a template or a pattern can be used", ErrInvalidTagFormat)
+	case template != "":
+		if strings.Count(template, VersionPlaceholder) != 1 {
+			return nil, fmt.Errorf(
+				"%w: template %q must contain %s exactly once",
+				ErrInvalidTagFormat,
+				template,
+				VersionPlaceholder,
+			)
+		}
+
+		prefix, suffix, _ := strings.Cut(template, VersionPlaceholder)
+		regex := regexp.MustCompile(
+			`^` + regexp.QuoteMeta(prefix) + `(?P<version>` + semVerPattern + `)` + regexp.QuoteMeta(suffix) + `$`,
+		)
+
+		return &TagFormat{pattern: regex, template: template}, nil
+	case pattern != "":
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidTagFormat, err)
+		}
+
+		if regex.SubexpIndex(versionGroupName) < 0 {
+			return nil, fmt.Errorf(
+				"%w: pattern %q must contain a named group (?P<%s>...)",
+				ErrInvalidTagFormat,
+				pattern,
+				versionGroupName,
+			)
+		}
+
+		return &TagFormat{pattern: regex}, nil
+	default:
+		return DefaultTagFormat, nil
+	}
+}
+
+// ExtractVersion parses a tag name and returns the version it contains.
+// Returns ErrInvalidSemVerTag if the tag does not match the format, or does not contain a valid version.
+func (tagFormat *TagFormat) ExtractVersion(tagName string) (*SemVer, error) {
+	start, end, found := tagFormat.versionSpan(tagName)
+	if !found {
+		return nil, fmt.Errorf("%w: %s does not match the tag format", ErrInvalidSemVerTag, tagName)
+	}
+
+	return ExtractSemVerStruct(tagName[start:end])
+}
+
+// Render returns the tag name for the given version.
+// A template is rendered by replacing the placeholder with the version.
+// For a pattern, including the DefaultTagFormat, the version of the latest tag name is replaced,
+// so prefix and suffix are kept. A pattern without a matching latest tag name renders the plain version.
+func (tagFormat *TagFormat) Render(version string, latestTagName string) string {
+	if tagFormat.template != "" {
+		return strings.Replace(tagFormat.template, VersionPlaceholder, version, 1)
+	}
+
+	start, end, found := tagFormat.versionSpan(latestTagName)
+	if !found {
+		return version
+	}
+
+	return latestTagName[:start] + version + latestTagName[end:]
+}
+
+// versionSpan returns the start and end index of the version within the tag name.
+func (tagFormat *TagFormat) versionSpan(tagName string) (int, int, bool) {
+	matches := tagFormat.pattern.FindStringSubmatchIndex(tagName)
+	if matches == nil {
+		return 0, 0, false
+	}
+
+	groupIndex := tagFormat.pattern.SubexpIndex(versionGroupName)
+
+	start, end := matches[2*groupIndex], matches[2*groupIndex+1]
+	if start < 0 {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
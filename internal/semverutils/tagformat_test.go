@@ -0,0 +1,169 @@
+package semverutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTagFormat_Default(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("", "")
+	require.NoError(t, err)
+	assert.Same(t, DefaultTagFormat, tagFormat)
+}
+
+func TestNewTagFormat_TemplateAndPattern(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("v{version}", `^v(?P<version>.+)$`)
+	require.ErrorIs(t, err, ErrInvalidTagFormat)
+	assert.Nil(t, tagFormat)
+}
+
+func TestNewTagFormat_TemplateWithoutPlaceholder(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("release-", "")
+	require.ErrorIs(t, err, ErrInvalidTagFormat)
+	assert.Nil(t, tagFormat)
+}
+
+func TestNewTagFormat_TemplateWithPlaceholderTwice(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("{version}-{version}", "")
+	require.ErrorIs(t, err, ErrInvalidTagFormat)
+	assert.Nil(t, tagFormat)
+}
+
+func TestNewTagFormat_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("", `^v(?P<version>.+$`)
+	require.ErrorIs(t, err, ErrInvalidTagFormat)
+	assert.Nil(t, tagFormat)
+}
+
+func TestNewTagFormat_PatternWithoutVersionGroup(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("", `^v(.+)$`)
+	require.ErrorIs(t, err, ErrInvalidTagFormat)
+	assert.Nil(t, tagFormat)
+}
+
+func TestTagFormatExtractVersion_Default(t *testing.T) {
+	t.Parallel()
+
+	version, err := DefaultTagFormat.ExtractVersion("v1.2.3-rc.1")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3-rc.1", version.String())
+
+	_, err = DefaultTagFormat.ExtractVersion("release-1.2.3")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+}
+
+func TestTagFormatExtractVersion_Template(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("api/v{version}", "")
+	require.NoError(t, err)
+
+	version, err := tagFormat.ExtractVersion("api/v1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", version.String())
+
+	_, err = tagFormat.ExtractVersion("v1.2.3")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+}
+
+func TestTagFormatExtractVersion_TemplateWithSuffix(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("{version}-final", "")
+	require.NoError(t, err)
+
+	version, err := tagFormat.ExtractVersion("1.2.3-rc.1-final")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3-rc.1", version.String())
+}
+
+func TestTagFormatExtractVersion_TemplateIsNotARegex(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("v.{version}", "")
+	require.NoError(t, err)
+
+	_, err = tagFormat.ExtractVersion("vx1.2.3")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+}
+
+func TestTagFormatExtractVersion_Pattern(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("", `^(?:api|web)/v(?P<version>.+)$`)
+	require.NoError(t, err)
+
+	version, err := tagFormat.ExtractVersion("web/v2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", version.String())
+}
+
+func TestTagFormatExtractVersion_PatternWithInvalidVersion(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("", `^api/v(?P<version>.+)$`)
+	require.NoError(t, err)
+
+	_, err = tagFormat.ExtractVersion("api/vlatest")
+	require.ErrorIs(t, err, ErrInvalidSemVerTag)
+}
+
+func TestTagFormatRender_Default(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "v1.3.0", DefaultTagFormat.Render("1.3.0", "v1.2.3"))
+}
+
+func TestTagFormatRender_DefaultWithoutPrefix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1.3.0", DefaultTagFormat.Render("1.3.0", "1.2.3"))
+}
+
+func TestTagFormatRender_DefaultWithoutLatestTag(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1.0.0", DefaultTagFormat.Render("1.0.0", ""))
+}
+
+func TestTagFormatRender_Template(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("release-{version}", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "release-1.3.0", tagFormat.Render("1.3.0", "release-1.2.3"))
+	assert.Equal(t, "release-1.3.0", tagFormat.Render("1.3.0", ""))
+}
+
+func TestTagFormatRender_PatternKeepsPrefixOfLatestTag(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("", `^(?:api|web)/v(?P<version>.+)$`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "web/v1.3.0", tagFormat.Render("1.3.0", "web/v1.2.3"))
+}
+
+func TestTagFormatRender_PatternWithoutLatestTag(t *testing.T) {
+	t.Parallel()
+
+	tagFormat, err := NewTagFormat("", `^api/v(?P<version>.+)$`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.3.0", tagFormat.Render("1.3.0", ""))
+}
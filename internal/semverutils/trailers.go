@@ -0,0 +1,76 @@
+package semverutils
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Trailers are footers of the commit message, see ConventionalCommit.Footers.
+const (
+	// ReleaseAsTrailer is the key of the trailer that pins the next version, e.g. "Release-As: 3.0.0".
+	ReleaseAsTrailer = "Release-As"
+	// VersionBumpTrailer is the key of the trailer that overrides the bump type of a single commit,
+	// e.g. "Version-Bump: none".
+	VersionBumpTrailer = "Version-Bump"
+)
+
+// ReleaseAsVersion returns the highest valid version pinned by a ReleaseAsTrailer of the commit messages,
+// or nil if no commit pins a version.
+func ReleaseAsVersion(commitMessages []string) *SemVer {
+	var releaseAs *SemVer
+
+	for _, message := range commitMessages {
+		value, found := ParseConventionalCommit(message).Footer(ReleaseAsTrailer)
+		if !found {
+			continue
+		}
+
+		semVer, err := ExtractSemVerStruct(value)
+		if err != nil {
+			log.WithField("commitMessage", message).Warnf("Ignoring %s trailer: %v", ReleaseAsTrailer, err)
+
+			continue
+		}
+
+		if releaseAs == nil || semVer.Compare(releaseAs) > 0 {
+			releaseAs = semVer
+		}
+	}
+
+	return releaseAs
+}
+
+// PinnedVersion returns the version pinned by a ReleaseAsTrailer of the commit messages, see ReleaseAsVersion,
+// or nil if no commit pins a version greater than the latest version.
+func PinnedVersion(latestVersion *SemVer, commitMessages []string) *SemVer {
+	releaseAs := ReleaseAsVersion(commitMessages)
+	if releaseAs == nil {
+		return nil
+	}
+
+	if releaseAs.Compare(latestVersion) <= 0 {
+		log.WithFields(log.Fields{"version": releaseAs.String(), "latestVersion": latestVersion.String()}).
+			Warnf("Ignoring %s trailer, since the version is not greater than the latest version", ReleaseAsTrailer)
+
+		return nil
+	}
+
+	return releaseAs
+}
+
+// versionBumpOverride returns the bump type given by the VersionBumpTrailer of the commit, if any.
+// Returns ErrInvalidBumpType if the trailer does not name a bump type.
+func versionBumpOverride(commit ConventionalCommit) (BumpType, bool, error) {
+	value, found := commit.Footer(VersionBumpTrailer)
+	if !found {
+		return NoBump, false, nil
+	}
+
+	bumpType, err := ParseBumpType(strings.ToLower(value))
+	if err != nil {
+		return NoBump, true, err
+	}
+
+	return bumpType, true, nil
+}
@@ -0,0 +1,130 @@
+package semverutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseAsVersion(t *testing.T) {
+	t.Parallel()
+
+	releaseAs := ReleaseAsVersion([]string{
+		"fix: bug\n\nRelease-As: 2.0.0",
+		"feat: feature\n\nrelease-as: v3.0.0",
+		"chore: cleanup\n\nRelease-As: 2.5.0",
+	})
+	require.NotNil(t, releaseAs)
+	assert.Equal(t, "3.0.0", releaseAs.String())
+}
+
+func TestReleaseAsVersion_Invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, ReleaseAsVersion([]string{"fix: bug\n\nRelease-As: next"}))
+}
+
+func TestPinnedVersion_NotGreater(t *testing.T) {
+	t.Parallel()
+
+	latestVersion, err := ExtractSemVerStruct("3.0.0")
+	require.NoError(t, err)
+
+	assert.Nil(t, PinnedVersion(latestVersion, []string{"fix: bug\n\nRelease-As: 3.0.0"}))
+}
+
+func TestCalculateNextVersion_ReleaseAs(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.3",
+		[]string{"feat!: breaking\n\nRelease-As: 3.0.0", "fix: bug"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.0", nextVersion)
+}
+
+func TestCalculateNextVersion_ReleaseAsWithoutBump(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.3",
+		[]string{"chore: release\n\nRelease-As: 1.5.0"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5.0", nextVersion)
+}
+
+func TestCalculateNextVersion_ReleaseAsNotGreater(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.3",
+		[]string{"fix: bug\n\nRelease-As: 1.0.0"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.4", nextVersion)
+}
+
+func TestCalculateNextVersion_VersionBumpOverridesPatterns(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.3",
+		[]string{"feat!: not really breaking\n\nVersion-Bump: minor", "fix: bug"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", nextVersion)
+}
+
+func TestCalculateNextVersion_VersionBumpNone(t *testing.T) {
+	t.Parallel()
+
+	_, err := CalculateNextVersion(
+		"1.2.3",
+		[]string{"feat: typo\n\nVersion-Bump: none"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.ErrorIs(t, err, ErrNoBump)
+}
+
+func TestCalculateNextVersion_VersionBumpRaisesBump(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.3",
+		[]string{"fix: removed option\n\nVersion-Bump: MAJOR"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}
+
+func TestCalculateNextVersion_InvalidVersionBumpUsesPatterns(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.3",
+		[]string{"feat: feature\n\nVersion-Bump: huge"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", nextVersion)
+}
+
+func TestCalculateNextVersion_ReleaseAsWithoutSpace(t *testing.T) {
+	t.Parallel()
+
+	nextVersion, err := CalculateNextVersion(
+		"1.2.0",
+		[]string{"fix: prepare release\n\nRelease-As:3.0.0"},
+		compileBumpConfig(t, DefaultBumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.0", nextVersion)
+}
@@ -0,0 +1,72 @@
+package semverutils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidTypeRule is returned when a key of BumpPatterns.Types is no commit type with an optional scope.
+var ErrInvalidTypeRule = errors.New("invalid type rule")
+
+// typeRuleRegex matches a key of BumpPatterns.Types, e.g. "feat" or "feat(internal)".
+var typeRuleRegex = regexp.MustCompile(`^(\w[\w-]*)(?:\(([^()]+)\))?$`)
+
+// TypeBumpType returns the bump type of the conventional commit according to the Types,
+// if a rule for its type and scope, or for its type only, exists.
+func (bumps BumpPatterns) TypeBumpType(commit ConventionalCommit) (BumpType, bool) {
+	if !commit.IsConventional() {
+		return NoBump, false
+	}
+
+	if commit.Scope != "" {
+		if bumpType, found := bumps.Types[commit.Type+"("+commit.Scope+")"]; found {
+			return bumpType, true
+		}
+	}
+
+	bumpType, found := bumps.Types[commit.Type]
+
+	return bumpType, found
+}
+
+// normalizeTypes returns the types with lowercased commit types, since commit types are not case sensitive.
+// Returns ErrInvalidTypeRule if a key is no commit type with an optional scope, or two keys only differ in case.
+func normalizeTypes(types map[string]BumpType) (map[string]BumpType, error) {
+	if types == nil {
+		return nil, nil
+	}
+
+	normalized := make(map[string]BumpType, len(types))
+
+	for rule, bumpType := range types {
+		match := typeRuleRegex.FindStringSubmatch(strings.TrimSpace(rule))
+		if match == nil {
+			return nil, &fieldError{
+				path: []string{"bumps", "types", rule},
+				err: fmt.Errorf(
+					"%w: %q, expected a type with an optional scope, e.g. feat(api)",
+					ErrInvalidTypeRule,
+					rule,
+				),
+			}
+		}
+
+		key := strings.ToLower(match[1])
+		if match[2] != "" {
+			key += "(" + strings.TrimSpace(match[2]) + ")"
+		}
+
+		if _, found := normalized[key]; found {
+			return nil, &fieldError{
+				path: []string{"bumps", "types", rule},
+				err:  fmt.Errorf("%w: %q is defined more than once", ErrInvalidTypeRule, key),
+			}
+		}
+
+		normalized[key] = bumpType
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,140 @@
+package semverutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBumpConfigFromFile_Types(t *testing.T) {
+	t.Parallel()
+
+	yamlContent := `
+bumps:
+  types:
+    feat: minor
+    Fix: patch
+    docs: none
+    feat(internal): patch
+`
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	require.NoError(t, os.WriteFile(tmpFile, []byte(yamlContent), 0o600))
+
+	config, err := LoadBumpConfigFromFile(tmpFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]BumpType{
+		"feat":           MinorBump,
+		"fix":            PatchBump,
+		"docs":           NoBump,
+		"feat(internal)": PatchBump,
+	}, config.Bumps.Types)
+	assert.Empty(t, config.Bumps.MinorPatterns)
+}
+
+func TestLoadBumpConfigFromFile_TypesInvalidBumpType(t *testing.T) {
+	t.Parallel()
+
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	require.NoError(t, os.WriteFile(tmpFile, []byte("bumps:\n  types:\n    feat: huge\n"), 0o600))
+
+	_, err := LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidBumpType)
+}
+
+func TestLoadBumpConfigFromFile_TypesInvalidRule(t *testing.T) {
+	t.Parallel()
+
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	require.NoError(t, os.WriteFile(tmpFile, []byte("bumps:\n  types:\n    \"feat!\": major\n"), 0o600))
+
+	_, err := LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidTypeRule)
+}
+
+func TestLoadBumpConfigFromFile_TypesDefinedTwice(t *testing.T) {
+	t.Parallel()
+
+	tmpFile := filepath.Join(t.TempDir(), "bumpconfig.yaml")
+	require.NoError(t, os.WriteFile(tmpFile, []byte("bumps:\n  types:\n    feat: minor\n    FEAT: patch\n"), 0o600))
+
+	_, err := LoadBumpConfigFromFile(tmpFile)
+	require.ErrorIs(t, err, ErrInvalidTypeRule)
+}
+
+func TestTypeBumpType_ScopeOverridesType(t *testing.T) {
+	t.Parallel()
+
+	bumps := BumpPatterns{Types: map[string]BumpType{"feat": MinorBump, "feat(internal)": PatchBump}}
+
+	bumpType, found := bumps.TypeBumpType(ParseConventionalCommit("feat(internal): helper"))
+	assert.True(t, found)
+	assert.Equal(t, PatchBump, bumpType)
+
+	bumpType, found = bumps.TypeBumpType(ParseConventionalCommit("feat(api): endpoint"))
+	assert.True(t, found)
+	assert.Equal(t, MinorBump, bumpType)
+}
+
+func TestTypeBumpType_NoRule(t *testing.T) {
+	t.Parallel()
+
+	bumps := BumpPatterns{Types: map[string]BumpType{"feat": MinorBump}}
+
+	_, found := bumps.TypeBumpType(ParseConventionalCommit("fix: bug"))
+	assert.False(t, found)
+
+	_, found = bumps.TypeBumpType(ParseConventionalCommit("Update README"))
+	assert.False(t, found)
+}
+
+func TestCalculateNextVersion_Types(t *testing.T) {
+	t.Parallel()
+
+	bumpConfig := BumpConfig{
+		Bumps: BumpPatterns{Types: map[string]BumpType{"feat": MinorBump, "perf": PatchBump, "docs": NoBump}},
+	}
+
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"docs: readme", "perf: faster"},
+		compileBumpConfig(t, bumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1", nextVersion)
+}
+
+func TestCalculateNextVersion_TypesTakePrecedenceOverPatterns(t *testing.T) {
+	t.Parallel()
+
+	bumpConfig := DefaultBumpConfig
+	bumpConfig.Bumps.Types = map[string]BumpType{"feat(internal)": PatchBump}
+
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"feat(internal): helper", "fix: bug"},
+		compileBumpConfig(t, bumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1", nextVersion)
+
+	nextVersion, err = CalculateNextVersion("1.0.0", []string{"feat(api): endpoint"}, compileBumpConfig(t, bumpConfig))
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", nextVersion)
+}
+
+func TestCalculateNextVersion_TypesBreakingChange(t *testing.T) {
+	t.Parallel()
+
+	bumpConfig := BumpConfig{Bumps: BumpPatterns{Types: map[string]BumpType{"docs": NoBump}, Breaking: MajorBump}}
+
+	nextVersion, err := CalculateNextVersion(
+		"1.0.0",
+		[]string{"docs!: drop old guide"},
+		compileBumpConfig(t, bumpConfig),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", nextVersion)
+}